@@ -0,0 +1,493 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: driver/v1/driver.proto
+
+package driverv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// DriverClient is the client API for Driver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DriverClient interface {
+	GetClosest(ctx context.Context, in *GetClosestRequest, opts ...grpc.CallOption) (*DriverLocationList, error)
+	GetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*DriverStatusMetadata, error)
+	SetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*Empty, error)
+	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*DriverLocation, error)
+	GoOnline(ctx context.Context, in *ShiftRequest, opts ...grpc.CallOption) (*Shift, error)
+	GoOffline(ctx context.Context, in *ShiftRequest, opts ...grpc.CallOption) (*Shift, error)
+	ListOnline(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ShiftList, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Driver_WatchClient, error)
+	// Session is opened by the driver's device, the first message must be a SessionStart
+	Session(ctx context.Context, opts ...grpc.CallOption) (Driver_SessionClient, error)
+	// Offer sends the ride to the driver's session and waits for their answer until the call's deadline
+	Offer(ctx context.Context, in *RideOffer, opts ...grpc.CallOption) (*OfferReply, error)
+}
+
+type driverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDriverClient(cc grpc.ClientConnInterface) DriverClient {
+	return &driverClient{cc}
+}
+
+func (c *driverClient) GetClosest(ctx context.Context, in *GetClosestRequest, opts ...grpc.CallOption) (*DriverLocationList, error) {
+	out := new(DriverLocationList)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/GetClosest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) GetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*DriverStatusMetadata, error) {
+	out := new(DriverStatusMetadata)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) SetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/SetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*DriverLocation, error) {
+	out := new(DriverLocation)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/Reserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) GoOnline(ctx context.Context, in *ShiftRequest, opts ...grpc.CallOption) (*Shift, error) {
+	out := new(Shift)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/GoOnline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) GoOffline(ctx context.Context, in *ShiftRequest, opts ...grpc.CallOption) (*Shift, error) {
+	out := new(Shift)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/GoOffline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) ListOnline(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ShiftList, error) {
+	out := new(ShiftList)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/ListOnline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Driver_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Driver_ServiceDesc.Streams[0], "/driver.v1.Driver/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driverWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Driver_WatchClient interface {
+	Recv() (*DriverLocation, error)
+	grpc.ClientStream
+}
+
+type driverWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *driverWatchClient) Recv() (*DriverLocation, error) {
+	m := new(DriverLocation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *driverClient) Session(ctx context.Context, opts ...grpc.CallOption) (Driver_SessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Driver_ServiceDesc.Streams[1], "/driver.v1.Driver/Session", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driverSessionClient{stream}
+	return x, nil
+}
+
+type Driver_SessionClient interface {
+	Send(*DriverSessionRequest) error
+	Recv() (*DriverSessionResponse, error)
+	grpc.ClientStream
+}
+
+type driverSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *driverSessionClient) Send(m *DriverSessionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *driverSessionClient) Recv() (*DriverSessionResponse, error) {
+	m := new(DriverSessionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *driverClient) Offer(ctx context.Context, in *RideOffer, opts ...grpc.CallOption) (*OfferReply, error) {
+	out := new(OfferReply)
+	err := c.cc.Invoke(ctx, "/driver.v1.Driver/Offer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DriverServer is the server API for Driver service.
+// All implementations must embed UnimplementedDriverServer
+// for forward compatibility
+type DriverServer interface {
+	GetClosest(context.Context, *GetClosestRequest) (*DriverLocationList, error)
+	GetStatus(context.Context, *DriverStatusMetadata) (*DriverStatusMetadata, error)
+	SetStatus(context.Context, *DriverStatusMetadata) (*Empty, error)
+	Reserve(context.Context, *ReserveRequest) (*DriverLocation, error)
+	GoOnline(context.Context, *ShiftRequest) (*Shift, error)
+	GoOffline(context.Context, *ShiftRequest) (*Shift, error)
+	ListOnline(context.Context, *Empty) (*ShiftList, error)
+	Watch(*WatchRequest, Driver_WatchServer) error
+	// Session is opened by the driver's device, the first message must be a SessionStart
+	Session(Driver_SessionServer) error
+	// Offer sends the ride to the driver's session and waits for their answer until the call's deadline
+	Offer(context.Context, *RideOffer) (*OfferReply, error)
+	mustEmbedUnimplementedDriverServer()
+}
+
+// UnimplementedDriverServer must be embedded to have forward compatible implementations.
+type UnimplementedDriverServer struct {
+}
+
+func (UnimplementedDriverServer) GetClosest(context.Context, *GetClosestRequest) (*DriverLocationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClosest not implemented")
+}
+func (UnimplementedDriverServer) GetStatus(context.Context, *DriverStatusMetadata) (*DriverStatusMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedDriverServer) SetStatus(context.Context, *DriverStatusMetadata) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStatus not implemented")
+}
+func (UnimplementedDriverServer) Reserve(context.Context, *ReserveRequest) (*DriverLocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedDriverServer) GoOnline(context.Context, *ShiftRequest) (*Shift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GoOnline not implemented")
+}
+func (UnimplementedDriverServer) GoOffline(context.Context, *ShiftRequest) (*Shift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GoOffline not implemented")
+}
+func (UnimplementedDriverServer) ListOnline(context.Context, *Empty) (*ShiftList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOnline not implemented")
+}
+func (UnimplementedDriverServer) Watch(*WatchRequest, Driver_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedDriverServer) Session(Driver_SessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+func (UnimplementedDriverServer) Offer(context.Context, *RideOffer) (*OfferReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Offer not implemented")
+}
+func (UnimplementedDriverServer) mustEmbedUnimplementedDriverServer() {}
+
+// UnsafeDriverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DriverServer will
+// result in compilation errors.
+type UnsafeDriverServer interface {
+	mustEmbedUnimplementedDriverServer()
+}
+
+func RegisterDriverServer(s grpc.ServiceRegistrar, srv DriverServer) {
+	s.RegisterService(&Driver_ServiceDesc, srv)
+}
+
+func _Driver_GetClosest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClosestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).GetClosest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/GetClosest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).GetClosest(ctx, req.(*GetClosestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DriverStatusMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).GetStatus(ctx, req.(*DriverStatusMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_SetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DriverStatusMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).SetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/SetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).SetStatus(ctx, req.(*DriverStatusMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/Reserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).Reserve(ctx, req.(*ReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_GoOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).GoOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/GoOnline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).GoOnline(ctx, req.(*ShiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_GoOffline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).GoOffline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/GoOffline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).GoOffline(ctx, req.(*ShiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_ListOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).ListOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/ListOnline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).ListOnline(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DriverServer).Watch(m, &driverWatchServer{stream})
+}
+
+type Driver_WatchServer interface {
+	Send(*DriverLocation) error
+	grpc.ServerStream
+}
+
+type driverWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *driverWatchServer) Send(m *DriverLocation) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Driver_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DriverServer).Session(&driverSessionServer{stream})
+}
+
+type Driver_SessionServer interface {
+	Send(*DriverSessionResponse) error
+	Recv() (*DriverSessionRequest, error)
+	grpc.ServerStream
+}
+
+type driverSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *driverSessionServer) Send(m *DriverSessionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *driverSessionServer) Recv() (*DriverSessionRequest, error) {
+	m := new(DriverSessionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Driver_Offer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RideOffer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).Offer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/driver.v1.Driver/Offer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).Offer(ctx, req.(*RideOffer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Driver_ServiceDesc is the grpc.ServiceDesc for Driver service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Driver_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "driver.v1.Driver",
+	HandlerType: (*DriverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetClosest",
+			Handler:    _Driver_GetClosest_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _Driver_GetStatus_Handler,
+		},
+		{
+			MethodName: "SetStatus",
+			Handler:    _Driver_SetStatus_Handler,
+		},
+		{
+			MethodName: "Reserve",
+			Handler:    _Driver_Reserve_Handler,
+		},
+		{
+			MethodName: "GoOnline",
+			Handler:    _Driver_GoOnline_Handler,
+		},
+		{
+			MethodName: "GoOffline",
+			Handler:    _Driver_GoOffline_Handler,
+		},
+		{
+			MethodName: "ListOnline",
+			Handler:    _Driver_ListOnline_Handler,
+		},
+		{
+			MethodName: "Offer",
+			Handler:    _Driver_Offer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Driver_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Session",
+			Handler:       _Driver_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "driver/v1/driver.proto",
+}
@@ -0,0 +1,1036 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: ride/v1/ride.proto
+
+package ridev1
+
+import (
+	v1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RideState int32
+
+const (
+	RideState_REQUESTED       RideState = 0
+	RideState_MATCHED         RideState = 1
+	RideState_DRIVER_EN_ROUTE RideState = 2
+	RideState_IN_PROGRESS     RideState = 3
+	RideState_COMPLETED       RideState = 4
+	RideState_CANCELLED       RideState = 5
+	RideState_ABORTED         RideState = 6
+)
+
+// Enum value maps for RideState.
+var (
+	RideState_name = map[int32]string{
+		0: "REQUESTED",
+		1: "MATCHED",
+		2: "DRIVER_EN_ROUTE",
+		3: "IN_PROGRESS",
+		4: "COMPLETED",
+		5: "CANCELLED",
+		6: "ABORTED",
+	}
+	RideState_value = map[string]int32{
+		"REQUESTED":       0,
+		"MATCHED":         1,
+		"DRIVER_EN_ROUTE": 2,
+		"IN_PROGRESS":     3,
+		"COMPLETED":       4,
+		"CANCELLED":       5,
+		"ABORTED":         6,
+	}
+)
+
+func (x RideState) Enum() *RideState {
+	p := new(RideState)
+	*p = x
+	return p
+}
+
+func (x RideState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RideState) Descriptor() protoreflect.EnumDescriptor {
+	return file_ride_v1_ride_proto_enumTypes[0].Descriptor()
+}
+
+func (RideState) Type() protoreflect.EnumType {
+	return &file_ride_v1_ride_proto_enumTypes[0]
+}
+
+func (x RideState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RideState.Descriptor instead.
+func (RideState) EnumDescriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{0}
+}
+
+type CancelActor int32
+
+const (
+	CancelActor_RIDER  CancelActor = 0
+	CancelActor_DRIVER CancelActor = 1
+	CancelActor_SYSTEM CancelActor = 2
+)
+
+// Enum value maps for CancelActor.
+var (
+	CancelActor_name = map[int32]string{
+		0: "RIDER",
+		1: "DRIVER",
+		2: "SYSTEM",
+	}
+	CancelActor_value = map[string]int32{
+		"RIDER":  0,
+		"DRIVER": 1,
+		"SYSTEM": 2,
+	}
+)
+
+func (x CancelActor) Enum() *CancelActor {
+	p := new(CancelActor)
+	*p = x
+	return p
+}
+
+func (x CancelActor) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancelActor) Descriptor() protoreflect.EnumDescriptor {
+	return file_ride_v1_ride_proto_enumTypes[1].Descriptor()
+}
+
+func (CancelActor) Type() protoreflect.EnumType {
+	return &file_ride_v1_ride_proto_enumTypes[1]
+}
+
+func (x CancelActor) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancelActor.Descriptor instead.
+func (CancelActor) EnumDescriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{1}
+}
+
+type StartRideRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username      string               `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	StartLocation *v1.LocationMetadata `protobuf:"bytes,2,opt,name=startLocation,proto3" json:"startLocation,omitempty"`
+	EndLocation   *v1.LocationMetadata `protobuf:"bytes,3,opt,name=endLocation,proto3" json:"endLocation,omitempty"`
+}
+
+func (x *StartRideRequest) Reset() {
+	*x = StartRideRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRideRequest) ProtoMessage() {}
+
+func (x *StartRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRideRequest.ProtoReflect.Descriptor instead.
+func (*StartRideRequest) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StartRideRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StartRideRequest) GetStartLocation() *v1.LocationMetadata {
+	if x != nil {
+		return x.StartLocation
+	}
+	return nil
+}
+
+func (x *StartRideRequest) GetEndLocation() *v1.LocationMetadata {
+	if x != nil {
+		return x.EndLocation
+	}
+	return nil
+}
+
+// PlannedRoute is the route the driver is expected to drive on the current leg, to the pickup or to the destination
+type PlannedRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the points of the route encoded with the polyline algorithm of google maps, 5 decimals
+	Polyline string `protobuf:"bytes,1,opt,name=polyline,proto3" json:"polyline,omitempty"`
+	// km along the roads
+	Distance   float64 `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	EtaSeconds int64   `protobuf:"varint,3,opt,name=etaSeconds,proto3" json:"etaSeconds,omitempty"`
+}
+
+func (x *PlannedRoute) Reset() {
+	*x = PlannedRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlannedRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedRoute) ProtoMessage() {}
+
+func (x *PlannedRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedRoute.ProtoReflect.Descriptor instead.
+func (*PlannedRoute) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlannedRoute) GetPolyline() string {
+	if x != nil {
+		return x.Polyline
+	}
+	return ""
+}
+
+func (x *PlannedRoute) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *PlannedRoute) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+type StartRideResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matched           bool               `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`
+	Location          *v1.DriverLocation `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	RideId            string             `protobuf:"bytes,3,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	State             RideState          `protobuf:"varint,4,opt,name=state,proto3,enum=ride.v1.RideState" json:"state,omitempty"`
+	RemainingDistance float64            `protobuf:"fixed64,5,opt,name=remainingDistance,proto3" json:"remainingDistance,omitempty"`
+	EtaSeconds        int64              `protobuf:"varint,6,opt,name=etaSeconds,proto3" json:"etaSeconds,omitempty"`
+	// set when a leg starts and when the driver strays from the route
+	Route *PlannedRoute `protobuf:"bytes,7,opt,name=route,proto3" json:"route,omitempty"`
+	// the route was planned again from where the driver strayed to
+	Rerouted bool `protobuf:"varint,8,opt,name=rerouted,proto3" json:"rerouted,omitempty"`
+	// set when the ride is completed
+	Fare *Fare `protobuf:"bytes,9,opt,name=fare,proto3" json:"fare,omitempty"`
+}
+
+func (x *StartRideResponse) Reset() {
+	*x = StartRideResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartRideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRideResponse) ProtoMessage() {}
+
+func (x *StartRideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRideResponse.ProtoReflect.Descriptor instead.
+func (*StartRideResponse) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StartRideResponse) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *StartRideResponse) GetLocation() *v1.DriverLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *StartRideResponse) GetRideId() string {
+	if x != nil {
+		return x.RideId
+	}
+	return ""
+}
+
+func (x *StartRideResponse) GetState() RideState {
+	if x != nil {
+		return x.State
+	}
+	return RideState_REQUESTED
+}
+
+func (x *StartRideResponse) GetRemainingDistance() float64 {
+	if x != nil {
+		return x.RemainingDistance
+	}
+	return 0
+}
+
+func (x *StartRideResponse) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *StartRideResponse) GetRoute() *PlannedRoute {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *StartRideResponse) GetRerouted() bool {
+	if x != nil {
+		return x.Rerouted
+	}
+	return false
+}
+
+func (x *StartRideResponse) GetFare() *Fare {
+	if x != nil {
+		return x.Fare
+	}
+	return nil
+}
+
+// Fare is the price of a ride, the amounts are in the minor unit of the currency, e.g. bani for RON
+type Fare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// the city whose rate card priced the ride
+	City         string         `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Vehicle      v1.VehicleType `protobuf:"varint,3,opt,name=vehicle,proto3,enum=driver.v1.VehicleType" json:"vehicle,omitempty"`
+	BaseFare     int64          `protobuf:"varint,4,opt,name=baseFare,proto3" json:"baseFare,omitempty"`
+	DistanceFare int64          `protobuf:"varint,5,opt,name=distanceFare,proto3" json:"distanceFare,omitempty"`
+	TimeFare     int64          `protobuf:"varint,6,opt,name=timeFare,proto3" json:"timeFare,omitempty"`
+	// raises the fare of a short ride to the minimum one
+	MinimumFareSurcharge int64 `protobuf:"varint,7,opt,name=minimumFareSurcharge,proto3" json:"minimumFareSurcharge,omitempty"`
+	BookingFee           int64 `protobuf:"varint,8,opt,name=bookingFee,proto3" json:"bookingFee,omitempty"`
+	Total                int64 `protobuf:"varint,9,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *Fare) Reset() {
+	*x = Fare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fare) ProtoMessage() {}
+
+func (x *Fare) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fare.ProtoReflect.Descriptor instead.
+func (*Fare) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Fare) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Fare) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Fare) GetVehicle() v1.VehicleType {
+	if x != nil {
+		return x.Vehicle
+	}
+	return v1.VehicleType(0)
+}
+
+func (x *Fare) GetBaseFare() int64 {
+	if x != nil {
+		return x.BaseFare
+	}
+	return 0
+}
+
+func (x *Fare) GetDistanceFare() int64 {
+	if x != nil {
+		return x.DistanceFare
+	}
+	return 0
+}
+
+func (x *Fare) GetTimeFare() int64 {
+	if x != nil {
+		return x.TimeFare
+	}
+	return 0
+}
+
+func (x *Fare) GetMinimumFareSurcharge() int64 {
+	if x != nil {
+		return x.MinimumFareSurcharge
+	}
+	return 0
+}
+
+func (x *Fare) GetBookingFee() int64 {
+	if x != nil {
+		return x.BookingFee
+	}
+	return 0
+}
+
+func (x *Fare) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type EstimateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartLocation *v1.LocationMetadata `protobuf:"bytes,1,opt,name=startLocation,proto3" json:"startLocation,omitempty"`
+	EndLocation   *v1.LocationMetadata `protobuf:"bytes,2,opt,name=endLocation,proto3" json:"endLocation,omitempty"`
+	// the vehicle classes to price, all the ones of the city when empty
+	VehicleTypes []v1.VehicleType `protobuf:"varint,3,rep,packed,name=vehicleTypes,proto3,enum=driver.v1.VehicleType" json:"vehicleTypes,omitempty"`
+}
+
+func (x *EstimateRequest) Reset() {
+	*x = EstimateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateRequest) ProtoMessage() {}
+
+func (x *EstimateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateRequest.ProtoReflect.Descriptor instead.
+func (*EstimateRequest) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EstimateRequest) GetStartLocation() *v1.LocationMetadata {
+	if x != nil {
+		return x.StartLocation
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetEndLocation() *v1.LocationMetadata {
+	if x != nil {
+		return x.EndLocation
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetVehicleTypes() []v1.VehicleType {
+	if x != nil {
+		return x.VehicleTypes
+	}
+	return nil
+}
+
+type EstimateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the route the ride would take
+	Route *PlannedRoute `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	Fares []*Fare       `protobuf:"bytes,2,rep,name=fares,proto3" json:"fares,omitempty"`
+}
+
+func (x *EstimateResponse) Reset() {
+	*x = EstimateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateResponse) ProtoMessage() {}
+
+func (x *EstimateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateResponse.ProtoReflect.Descriptor instead.
+func (*EstimateResponse) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EstimateResponse) GetRoute() *PlannedRoute {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *EstimateResponse) GetFares() []*Fare {
+	if x != nil {
+		return x.Fares
+	}
+	return nil
+}
+
+type CancelRideRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RideId string      `protobuf:"bytes,1,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	Reason string      `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor  CancelActor `protobuf:"varint,3,opt,name=actor,proto3,enum=ride.v1.CancelActor" json:"actor,omitempty"`
+}
+
+func (x *CancelRideRequest) Reset() {
+	*x = CancelRideRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRideRequest) ProtoMessage() {}
+
+func (x *CancelRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRideRequest.ProtoReflect.Descriptor instead.
+func (*CancelRideRequest) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelRideRequest) GetRideId() string {
+	if x != nil {
+		return x.RideId
+	}
+	return ""
+}
+
+func (x *CancelRideRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelRideRequest) GetActor() CancelActor {
+	if x != nil {
+		return x.Actor
+	}
+	return CancelActor_RIDER
+}
+
+type CancelRideResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RideId string    `protobuf:"bytes,1,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	State  RideState `protobuf:"varint,2,opt,name=state,proto3,enum=ride.v1.RideState" json:"state,omitempty"`
+}
+
+func (x *CancelRideResponse) Reset() {
+	*x = CancelRideResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ride_v1_ride_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelRideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRideResponse) ProtoMessage() {}
+
+func (x *CancelRideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ride_v1_ride_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRideResponse.ProtoReflect.Descriptor instead.
+func (*CancelRideResponse) Descriptor() ([]byte, []int) {
+	return file_ride_v1_ride_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelRideResponse) GetRideId() string {
+	if x != nil {
+		return x.RideId
+	}
+	return ""
+}
+
+func (x *CancelRideResponse) GetState() RideState {
+	if x != nil {
+		return x.State
+	}
+	return RideState_REQUESTED
+}
+
+var File_ride_v1_ride_proto protoreflect.FileDescriptor
+
+var file_ride_v1_ride_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x16, 0x64,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0b, 0x65, 0x6e, 0x64,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x65, 0x6e, 0x64,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x6e,
+	0x6e, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x79,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x79,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x74, 0x61, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x74, 0x61, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x22, 0xe0, 0x02, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x12, 0x35, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x12,
+	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x64, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x74, 0x61, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x74, 0x61,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x05, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64,
+	0x12, 0x21, 0x0a, 0x04, 0x66, 0x61, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x72, 0x65, 0x52, 0x04, 0x66,
+	0x61, 0x72, 0x65, 0x22, 0xae, 0x02, 0x0a, 0x04, 0x46, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x07,
+	0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x46, 0x61, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x46, 0x61, 0x72, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x46, 0x61, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x46, 0x61, 0x72, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x46, 0x61, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x46, 0x61, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x6d, 0x69,
+	0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x46, 0x61, 0x72, 0x65, 0x53, 0x75, 0x72, 0x63, 0x68, 0x61, 0x72,
+	0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75,
+	0x6d, 0x46, 0x61, 0x72, 0x65, 0x53, 0x75, 0x72, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x22, 0xcf, 0x01, 0x0a, 0x0f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0b, 0x65,
+	0x6e, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x65,
+	0x6e, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0c, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x16, 0x2e, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x10, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x66, 0x61, 0x72, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x61, 0x72, 0x65, 0x52, 0x05, 0x66, 0x61, 0x72, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x11,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x2a, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x56, 0x0a,
+	0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x69, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x69, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2a, 0x78, 0x0a, 0x09, 0x52, 0x69, 0x64, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13,
+	0x0a, 0x0f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x5f, 0x52, 0x4f, 0x55, 0x54,
+	0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45,
+	0x53, 0x53, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45,
+	0x44, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44,
+	0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x06, 0x2a,
+	0x30, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x09,
+	0x0a, 0x05, 0x52, 0x49, 0x44, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x52, 0x49,
+	0x56, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10,
+	0x02, 0x32, 0xcc, 0x01, 0x0a, 0x04, 0x52, 0x69, 0x64, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x69,
+	0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x06,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x1a, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3f, 0x0a, 0x08, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x69,
+	0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x6c, 0x65, 0x78, 0x63, 0x6f, 0x67, 0x6f, 0x6a, 0x6f, 0x63, 0x61, 0x72, 0x75, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x2d, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2d, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x76,
+	0x31, 0x3b, 0x72, 0x69, 0x64, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ride_v1_ride_proto_rawDescOnce sync.Once
+	file_ride_v1_ride_proto_rawDescData = file_ride_v1_ride_proto_rawDesc
+)
+
+func file_ride_v1_ride_proto_rawDescGZIP() []byte {
+	file_ride_v1_ride_proto_rawDescOnce.Do(func() {
+		file_ride_v1_ride_proto_rawDescData = protoimpl.X.CompressGZIP(file_ride_v1_ride_proto_rawDescData)
+	})
+	return file_ride_v1_ride_proto_rawDescData
+}
+
+var file_ride_v1_ride_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_ride_v1_ride_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_ride_v1_ride_proto_goTypes = []interface{}{
+	(RideState)(0),              // 0: ride.v1.RideState
+	(CancelActor)(0),            // 1: ride.v1.CancelActor
+	(*StartRideRequest)(nil),    // 2: ride.v1.StartRideRequest
+	(*PlannedRoute)(nil),        // 3: ride.v1.PlannedRoute
+	(*StartRideResponse)(nil),   // 4: ride.v1.StartRideResponse
+	(*Fare)(nil),                // 5: ride.v1.Fare
+	(*EstimateRequest)(nil),     // 6: ride.v1.EstimateRequest
+	(*EstimateResponse)(nil),    // 7: ride.v1.EstimateResponse
+	(*CancelRideRequest)(nil),   // 8: ride.v1.CancelRideRequest
+	(*CancelRideResponse)(nil),  // 9: ride.v1.CancelRideResponse
+	(*v1.LocationMetadata)(nil), // 10: driver.v1.LocationMetadata
+	(*v1.DriverLocation)(nil),   // 11: driver.v1.DriverLocation
+	(v1.VehicleType)(0),         // 12: driver.v1.VehicleType
+}
+var file_ride_v1_ride_proto_depIdxs = []int32{
+	10, // 0: ride.v1.StartRideRequest.startLocation:type_name -> driver.v1.LocationMetadata
+	10, // 1: ride.v1.StartRideRequest.endLocation:type_name -> driver.v1.LocationMetadata
+	11, // 2: ride.v1.StartRideResponse.location:type_name -> driver.v1.DriverLocation
+	0,  // 3: ride.v1.StartRideResponse.state:type_name -> ride.v1.RideState
+	3,  // 4: ride.v1.StartRideResponse.route:type_name -> ride.v1.PlannedRoute
+	5,  // 5: ride.v1.StartRideResponse.fare:type_name -> ride.v1.Fare
+	12, // 6: ride.v1.Fare.vehicle:type_name -> driver.v1.VehicleType
+	10, // 7: ride.v1.EstimateRequest.startLocation:type_name -> driver.v1.LocationMetadata
+	10, // 8: ride.v1.EstimateRequest.endLocation:type_name -> driver.v1.LocationMetadata
+	12, // 9: ride.v1.EstimateRequest.vehicleTypes:type_name -> driver.v1.VehicleType
+	3,  // 10: ride.v1.EstimateResponse.route:type_name -> ride.v1.PlannedRoute
+	5,  // 11: ride.v1.EstimateResponse.fares:type_name -> ride.v1.Fare
+	1,  // 12: ride.v1.CancelRideRequest.actor:type_name -> ride.v1.CancelActor
+	0,  // 13: ride.v1.CancelRideResponse.state:type_name -> ride.v1.RideState
+	2,  // 14: ride.v1.Ride.Start:input_type -> ride.v1.StartRideRequest
+	8,  // 15: ride.v1.Ride.Cancel:input_type -> ride.v1.CancelRideRequest
+	6,  // 16: ride.v1.Ride.Estimate:input_type -> ride.v1.EstimateRequest
+	4,  // 17: ride.v1.Ride.Start:output_type -> ride.v1.StartRideResponse
+	9,  // 18: ride.v1.Ride.Cancel:output_type -> ride.v1.CancelRideResponse
+	7,  // 19: ride.v1.Ride.Estimate:output_type -> ride.v1.EstimateResponse
+	17, // [17:20] is the sub-list for method output_type
+	14, // [14:17] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_ride_v1_ride_proto_init() }
+func file_ride_v1_ride_proto_init() {
+	if File_ride_v1_ride_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ride_v1_ride_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRideRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlannedRoute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRideResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelRideRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ride_v1_ride_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelRideResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ride_v1_ride_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ride_v1_ride_proto_goTypes,
+		DependencyIndexes: file_ride_v1_ride_proto_depIdxs,
+		EnumInfos:         file_ride_v1_ride_proto_enumTypes,
+		MessageInfos:      file_ride_v1_ride_proto_msgTypes,
+	}.Build()
+	File_ride_v1_ride_proto = out.File
+	file_ride_v1_ride_proto_rawDesc = nil
+	file_ride_v1_ride_proto_goTypes = nil
+	file_ride_v1_ride_proto_depIdxs = nil
+}
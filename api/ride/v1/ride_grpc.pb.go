@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.2.0
 // - protoc             v3.19.4
-// source: ride.proto
+// source: ride/v1/ride.proto
 
-package pb
+package ridev1
 
 import (
 	context "context"
@@ -23,6 +23,9 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RideClient interface {
 	Start(ctx context.Context, in *StartRideRequest, opts ...grpc.CallOption) (Ride_StartClient, error)
+	Cancel(ctx context.Context, in *CancelRideRequest, opts ...grpc.CallOption) (*CancelRideResponse, error)
+	// Estimate prices a ride between two points for every vehicle class, before it is requested
+	Estimate(ctx context.Context, in *EstimateRequest, opts ...grpc.CallOption) (*EstimateResponse, error)
 }
 
 type rideClient struct {
@@ -34,7 +37,7 @@ func NewRideClient(cc grpc.ClientConnInterface) RideClient {
 }
 
 func (c *rideClient) Start(ctx context.Context, in *StartRideRequest, opts ...grpc.CallOption) (Ride_StartClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Ride_ServiceDesc.Streams[0], "/Ride/Start", opts...)
+	stream, err := c.cc.NewStream(ctx, &Ride_ServiceDesc.Streams[0], "/ride.v1.Ride/Start", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,11 +68,32 @@ func (x *rideStartClient) Recv() (*StartRideResponse, error) {
 	return m, nil
 }
 
+func (c *rideClient) Cancel(ctx context.Context, in *CancelRideRequest, opts ...grpc.CallOption) (*CancelRideResponse, error) {
+	out := new(CancelRideResponse)
+	err := c.cc.Invoke(ctx, "/ride.v1.Ride/Cancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideClient) Estimate(ctx context.Context, in *EstimateRequest, opts ...grpc.CallOption) (*EstimateResponse, error) {
+	out := new(EstimateResponse)
+	err := c.cc.Invoke(ctx, "/ride.v1.Ride/Estimate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RideServer is the server API for Ride service.
 // All implementations must embed UnimplementedRideServer
 // for forward compatibility
 type RideServer interface {
 	Start(*StartRideRequest, Ride_StartServer) error
+	Cancel(context.Context, *CancelRideRequest) (*CancelRideResponse, error)
+	// Estimate prices a ride between two points for every vehicle class, before it is requested
+	Estimate(context.Context, *EstimateRequest) (*EstimateResponse, error)
 	mustEmbedUnimplementedRideServer()
 }
 
@@ -80,6 +104,12 @@ type UnimplementedRideServer struct {
 func (UnimplementedRideServer) Start(*StartRideRequest, Ride_StartServer) error {
 	return status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
+func (UnimplementedRideServer) Cancel(context.Context, *CancelRideRequest) (*CancelRideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedRideServer) Estimate(context.Context, *EstimateRequest) (*EstimateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Estimate not implemented")
+}
 func (UnimplementedRideServer) mustEmbedUnimplementedRideServer() {}
 
 // UnsafeRideServer may be embedded to opt out of forward compatibility for this service.
@@ -114,13 +144,58 @@ func (x *rideStartServer) Send(m *StartRideResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Ride_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ride.v1.Ride/Cancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServer).Cancel(ctx, req.(*CancelRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ride_Estimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServer).Estimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ride.v1.Ride/Estimate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServer).Estimate(ctx, req.(*EstimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Ride_ServiceDesc is the grpc.ServiceDesc for Ride service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Ride_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "Ride",
+	ServiceName: "ride.v1.Ride",
 	HandlerType: (*RideServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Cancel",
+			Handler:    _Ride_Cancel_Handler,
+		},
+		{
+			MethodName: "Estimate",
+			Handler:    _Ride_Estimate_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Start",
@@ -128,5 +203,5 @@ var Ride_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 		},
 	},
-	Metadata: "ride.proto",
+	Metadata: "ride/v1/ride.proto",
 }
@@ -0,0 +1,37 @@
+module github.com/alexcogojocaru/cloud-computing-project/auth
+
+go 1.20
+
+require (
+	github.com/alexcogojocaru/cloud-computing-project/api v0.0.0-00010101000000-000000000000
+	github.com/alexcogojocaru/cloud-computing-project/pkg v0.0.0-00010101000000-000000000000
+	github.com/golang-jwt/jwt/v5 v5.0.0
+	github.com/google/uuid v1.3.0
+	github.com/redis/go-redis/v9 v9.0.4
+	go.uber.org/fx v1.19.3
+	go.uber.org/zap v1.24.0
+	golang.org/x/crypto v0.7.0
+	google.golang.org/grpc v1.54.0
+)
+
+require (
+	github.com/alicebob/miniredis/v2 v2.39.0 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/dig v1.16.1 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	golang.org/x/net v0.9.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	golang.org/x/text v0.9.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	google.golang.org/protobuf v1.30.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/alexcogojocaru/cloud-computing-project/api => ../../api
+	github.com/alexcogojocaru/cloud-computing-project/pkg => ../../pkg
+)
@@ -0,0 +1,120 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AUTHORIZATION_HEADER = "authorization"
+	BEARER_PREFIX        = "Bearer "
+)
+
+var (
+	ErrMissingToken = status.Error(codes.Unauthenticated, "missing bearer token")
+	ErrInvalidToken = status.Error(codes.Unauthenticated, "invalid bearer token")
+	ErrNotAllowed   = status.Error(codes.PermissionDenied, "role is not allowed to call this method")
+	ErrNotOwner     = status.Error(codes.PermissionDenied, "caller can only act as itself")
+)
+
+// Policy maps a full method name, like /driver.v1.Driver/SetStatus, to the roles allowed to call it.
+// Methods missing from the policy can't be called by anyone.
+type Policy map[string][]token.Role
+
+func (p Policy) allows(method string, role token.Role) bool {
+	for _, allowed := range p[method] {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+type Identity struct {
+	Subject string
+	Role    token.Role
+}
+
+type identityKey struct{}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// Owns reports if the caller can act on behalf of name, services and admins can act on behalf of anyone
+func Owns(ctx context.Context, name string) bool {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	switch identity.Role {
+	case token.RoleService, token.RoleAdmin:
+		return true
+	default:
+		return identity.Subject == name
+	}
+}
+
+func authorize(ctx context.Context, tokens *token.Manager, policy Policy, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AUTHORIZATION_HEADER)
+	if len(values) == 0 || !strings.HasPrefix(values[0], BEARER_PREFIX) {
+		return nil, ErrMissingToken
+	}
+
+	claims, err := tokens.Verify(strings.TrimPrefix(values[0], BEARER_PREFIX))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if !policy.allows(method, claims.Role) {
+		return nil, ErrNotAllowed
+	}
+
+	return WithIdentity(ctx, Identity{Subject: claims.Subject, Role: claims.Role}), nil
+}
+
+func Unary(tokens *token.Manager, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, tokens, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// authorizedStream replaces the stream context so handlers see the caller's identity
+type authorizedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func Stream(tokens *token.Manager, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), tokens, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+
+	"github.com/alexcogojocaru/cloud-computing-project/auth/service"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/zap"
+)
+
+func NewConfig() (*config.Config, error) {
+	return config.Load(os.Args[1:], config.Defaults(8083), config.RequireAuth)
+}
+
+func main() {
+	fx.New(
+		fx.WithLogger(func(log *zap.Logger) fxevent.Logger {
+			return &fxevent.ZapLogger{Logger: log}
+		}),
+		fx.Provide(
+			NewConfig,
+			service.NewAuthGrpcService,
+			service.NewAuthStore,
+			token.NewManagerFromConfig,
+			zap.NewExample,
+		), fx.Invoke(
+			func(*service.AuthGrpcService) {},
+		),
+	).Run()
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"time"
+
+	authv1 "github.com/alexcogojocaru/cloud-computing-project/api/auth/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/store"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MIN_PASSWORD_LENGTH = 8
+	REFRESH_TOKEN_BYTES = 32
+)
+
+var (
+	// the same error for an unknown user and a wrong password, the caller can't tell which accounts exist
+	ErrInvalidCredentials = status.Error(codes.Unauthenticated, "invalid credentials")
+	ErrInvalidRefresh     = status.Error(codes.Unauthenticated, "invalid refresh token")
+)
+
+var roles = map[authv1.Role]token.Role{
+	authv1.Role_RIDER:   token.RoleRider,
+	authv1.Role_DRIVER:  token.RoleDriver,
+	authv1.Role_SERVICE: token.RoleService,
+	authv1.Role_ADMIN:   token.RoleAdmin,
+}
+
+func roleToPb(role token.Role) authv1.Role {
+	for r, name := range roles {
+		if name == role {
+			return r
+		}
+	}
+	return authv1.Role_UNKNOWN
+}
+
+type AuthGrpcService struct {
+	authv1.UnimplementedAuthServer
+
+	log        *zap.Logger
+	store      store.Store
+	tokens     *token.Manager
+	refreshTTL time.Duration
+}
+
+func NewAuthGrpcService(
+	lc fx.Lifecycle,
+	log *zap.Logger,
+	store store.Store,
+	tokens *token.Manager,
+	cfg *config.Config,
+) *AuthGrpcService {
+	a := &AuthGrpcService{
+		log:        log,
+		store:      store,
+		tokens:     tokens,
+		refreshTTL: cfg.Auth.RefreshTokenTTL,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", cfg.Addr())
+			if err != nil {
+				log.Fatal("Cannot listen", zap.String("addr", cfg.Addr()), zap.Error(err))
+			}
+
+			grpcServer := grpc.NewServer()
+			authv1.RegisterAuthServer(grpcServer, a)
+
+			go func() {
+				log.Info("Starting grpc service...")
+				grpcServer.Serve(lis)
+			}()
+
+			return nil
+		},
+	})
+
+	return a
+}
+
+// Register creates rider and driver accounts, service and admin accounts can't sign themselves up
+func (a *AuthGrpcService) Register(ctx context.Context, req *authv1.RegisterRequest) (*authv1.Account, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if len(req.Password) < MIN_PASSWORD_LENGTH {
+		return nil, status.Errorf(codes.InvalidArgument, "password must have at least %d characters", MIN_PASSWORD_LENGTH)
+	}
+	if req.Role != authv1.Role_RIDER && req.Role != authv1.Role_DRIVER {
+		return nil, status.Error(codes.PermissionDenied, "only rider and driver accounts can register")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.store.CreateAccount(ctx, &store.Account{
+		Username:     req.Username,
+		PasswordHash: hash,
+		Role:         string(roles[req.Role]),
+		CreatedAt:    time.Now(),
+	})
+	if err == store.ErrExists {
+		return nil, status.Error(codes.AlreadyExists, "username is taken")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Info("Registered account", zap.String("username", req.Username), zap.String("role", req.Role.String()))
+
+	return &authv1.Account{Username: req.Username, Role: req.Role}, nil
+}
+
+func (a *AuthGrpcService) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.TokenPair, error) {
+	account, err := a.store.GetAccount(ctx, req.Username)
+	if err == store.ErrNotFound {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword(account.PasswordHash, []byte(req.Password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return a.issue(ctx, account)
+}
+
+// Refresh rotates the refresh token, a stolen token that was already used is worthless
+func (a *AuthGrpcService) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.TokenPair, error) {
+	refresh, err := a.store.TakeRefreshToken(ctx, hashToken(req.RefreshToken))
+	if err == store.ErrNotFound {
+		return nil, ErrInvalidRefresh
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// read the account again so a changed role is picked up
+	account, err := a.store.GetAccount(ctx, refresh.Username)
+	if err == store.ErrNotFound {
+		return nil, ErrInvalidRefresh
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return a.issue(ctx, account)
+}
+
+// Revoke ends the session of a refresh token, revoking an unknown token is not an error
+func (a *AuthGrpcService) Revoke(ctx context.Context, req *authv1.RevokeRequest) (*authv1.Empty, error) {
+	_, err := a.store.TakeRefreshToken(ctx, hashToken(req.RefreshToken))
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	return &authv1.Empty{}, nil
+}
+
+func (a *AuthGrpcService) issue(ctx context.Context, account *store.Account) (*authv1.TokenPair, error) {
+	role := token.Role(account.Role)
+
+	access, expiresAt, err := a.tokens.Issue(account.Username, role)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.store.SaveRefreshToken(ctx, &store.RefreshToken{
+		Hash:      hashToken(refresh),
+		Username:  account.Username,
+		ExpiresAt: time.Now().Add(a.refreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &authv1.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresAt:    expiresAt.UnixMilli(),
+		Role:         roleToPb(role),
+	}, nil
+}
+
+func newRefreshToken() (string, error) {
+	buf := make([]byte, REFRESH_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// only the hash of a refresh token is stored, a leaked store doesn't leak usable tokens
+func hashToken(refresh string) string {
+	sum := sha256.Sum256([]byte(refresh))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authv1 "github.com/alexcogojocaru/cloud-computing-project/api/auth/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/store"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestAuthService() *AuthGrpcService {
+	return &AuthGrpcService{
+		log:        zap.NewNop(),
+		store:      store.NewMemoryStore(),
+		tokens:     token.NewManager([]byte("test-secret"), time.Minute),
+		refreshTTL: time.Hour,
+	}
+}
+
+func TestAccountFlow(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAuthService()
+
+	_, err := a.Register(ctx, &authv1.RegisterRequest{Username: "ana", Password: "correct horse", Role: authv1.Role_RIDER})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = a.Register(ctx, &authv1.RegisterRequest{Username: "ana", Password: "correct horse", Role: authv1.Role_RIDER})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+
+	_, err = a.Login(ctx, &authv1.LoginRequest{Username: "ana", Password: "wrong password"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+
+	pair, err := a.Login(ctx, &authv1.LoginRequest{Username: "ana", Password: "correct horse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := a.tokens.Verify(pair.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "ana" || claims.Role != token.RoleRider {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	refreshed, err := a.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: pair.RefreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the refresh token was rotated, the old one can't be used twice
+	_, err = a.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: pair.RefreshToken})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+
+	_, err = a.Revoke(ctx, &authv1.RevokeRequest{RefreshToken: refreshed.RefreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = a.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestRegisterRejectsPrivilegedRoles(t *testing.T) {
+	a := newTestAuthService()
+
+	for _, role := range []authv1.Role{authv1.Role_SERVICE, authv1.Role_ADMIN, authv1.Role_UNKNOWN} {
+		_, err := a.Register(context.Background(), &authv1.RegisterRequest{Username: "mallory", Password: "password123", Role: role})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("role %s: expected PermissionDenied, got %v", role, err)
+		}
+	}
+}
+
+func TestVerifyRejectsForeignTokens(t *testing.T) {
+	signed, _, err := token.NewManager([]byte("other-secret"), time.Minute).Issue("ana", token.RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newTestAuthService().tokens.Verify(signed); err != token.ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/alexcogojocaru/cloud-computing-project/auth/store"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/rdb"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func NewAuthStore(lc fx.Lifecycle, log *zap.Logger, cfg *config.Config) (store.Store, error) {
+	if cfg.Auth.Store == "redis" {
+		client, err := rdb.New(lc, log, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info("Using redis auth store")
+		return store.NewRedisStore(client), nil
+	}
+
+	log.Info("Using in-memory auth store, accounts are lost on restart")
+	return store.NewMemoryStore(), nil
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryStore struct {
+	mu       sync.Mutex
+	accounts map[string]Account
+	tokens   map[string]RefreshToken
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		accounts: make(map[string]Account),
+		tokens:   make(map[string]RefreshToken),
+	}
+}
+
+func (s *MemoryStore) CreateAccount(ctx context.Context, account *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.accounts[account.Username]; ok {
+		return ErrExists
+	}
+	s.accounts[account.Username] = *account
+
+	return nil
+}
+
+func (s *MemoryStore) GetAccount(ctx context.Context, username string) (*Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[username]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &account, nil
+}
+
+func (s *MemoryStore) SaveRefreshToken(ctx context.Context, token *RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[token.Hash] = *token
+
+	return nil
+}
+
+func (s *MemoryStore) TakeRefreshToken(ctx context.Context, hash string) (*RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(s.tokens, hash)
+
+	if time.Now().After(token.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func accountKey(username string) string {
+	return "auth/accounts/" + username
+}
+
+func refreshKey(hash string) string {
+	return "auth/refresh/" + hash
+}
+
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func (s *RedisStore) CreateAccount(ctx context.Context, account *Account) error {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	created, err := s.rdb.SetNX(ctx, accountKey(account.Username), data, 0).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrExists
+	}
+
+	return nil
+}
+
+func (s *RedisStore) GetAccount(ctx context.Context, username string) (*Account, error) {
+	data, err := s.rdb.Get(ctx, accountKey(username)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{}
+	if err := json.Unmarshal(data, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *RedisStore) SaveRefreshToken(ctx context.Context, token *RefreshToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	// redis drops the token on its own once it expires
+	return s.rdb.Set(ctx, refreshKey(token.Hash), data, time.Until(token.ExpiresAt)).Err()
+}
+
+func (s *RedisStore) TakeRefreshToken(ctx context.Context, hash string) (*RefreshToken, error) {
+	data, err := s.rdb.GetDel(ctx, refreshKey(hash)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &RefreshToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+
+	return token, nil
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("Not found")
+	ErrExists   = errors.New("Already exists")
+)
+
+type Account struct {
+	Username     string    `json:"username"`
+	PasswordHash []byte    `json:"passwordHash"`
+	Role         string    `json:"role"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// RefreshToken is stored by the hash of the opaque token, the token itself is only known to the client
+type RefreshToken struct {
+	Hash      string    `json:"hash"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type Store interface {
+	CreateAccount(ctx context.Context, account *Account) error
+	GetAccount(ctx context.Context, username string) (*Account, error)
+	SaveRefreshToken(ctx context.Context, token *RefreshToken) error
+	// TakeRefreshToken fetches and deletes the token in one step so it can be used only once
+	TakeRefreshToken(ctx context.Context, hash string) (*RefreshToken, error)
+}
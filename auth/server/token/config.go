@@ -0,0 +1,8 @@
+package token
+
+import "github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+
+// NewManagerFromConfig expects a config validated with config.RequireAuth
+func NewManagerFromConfig(cfg *config.Config) *Manager {
+	return NewManager([]byte(cfg.Auth.Secret.Value()), cfg.Auth.AccessTokenTTL)
+}
@@ -0,0 +1,79 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	ISSUER = "cloud-computing-project/auth"
+)
+
+type Role string
+
+const (
+	RoleRider   Role = "rider"
+	RoleDriver  Role = "driver"
+	RoleService Role = "service"
+	RoleAdmin   Role = "admin"
+)
+
+var (
+	ErrInvalidToken = errors.New("Invalid token")
+)
+
+type Claims struct {
+	Role Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Manager signs and verifies HS256 access tokens, every service holding the same secret can verify them
+type Manager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewManager(secret []byte, ttl time.Duration) *Manager {
+	return &Manager{
+		secret: secret,
+		ttl:    ttl,
+	}
+}
+
+func (m *Manager) Issue(subject string, role Role) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    ISSUER,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (m *Manager) Verify(signed string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(signed, claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}), jwt.WithIssuer(ISSUER))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
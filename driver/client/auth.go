@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	authv1 "github.com/alexcogojocaru/cloud-computing-project/api/auth/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// refresh the access token a bit before it expires so it doesn't expire in flight
+const RENEW_BEFORE = 30 * time.Second
+
+// tokenSource logs in once and keeps the access token fresh, it implements credentials.PerRPCCredentials
+type tokenSource struct {
+	auth authv1.AuthClient
+
+	mu   sync.Mutex
+	pair *authv1.TokenPair
+}
+
+// login registers the driver account on first use and logs in with it
+func login(ctx context.Context, auth authv1.AuthClient, username, password string) (*tokenSource, error) {
+	_, err := auth.Register(ctx, &authv1.RegisterRequest{
+		Username: username,
+		Password: password,
+		Role:     authv1.Role_DRIVER,
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return nil, err
+	}
+
+	pair, err := auth.Login(ctx, &authv1.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenSource{auth: auth, pair: pair}, nil
+}
+
+func (t *tokenSource) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Until(time.UnixMilli(t.pair.ExpiresAt)) < RENEW_BEFORE {
+		pair, err := t.auth.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: t.pair.RefreshToken})
+		if err != nil {
+			return nil, err
+		}
+		t.pair = pair
+	}
+
+	return map[string]string{"authorization": "Bearer " + t.pair.AccessToken}, nil
+}
+
+func (t *tokenSource) RequireTransportSecurity() bool {
+	return false
+}
@@ -3,31 +3,22 @@ module github.com/alexcogojocaru/cloud-computing-project/driver/client
 go 1.19
 
 require (
-	cloud.google.com/go/pubsub v1.30.1
+	github.com/alexcogojocaru/cloud-computing-project/api v0.0.0-00010101000000-000000000000
+	github.com/alexcogojocaru/cloud-computing-project/pkg v0.0.0-00010101000000-000000000000
 	github.com/google/uuid v1.3.0
 	google.golang.org/grpc v1.54.0
-	google.golang.org/protobuf v1.30.0
 )
 
 require (
-	cloud.google.com/go v0.110.0 // indirect
-	cloud.google.com/go/compute v1.19.0 // indirect
-	cloud.google.com/go/compute/metadata v0.2.3 // indirect
-	cloud.google.com/go/iam v0.13.0 // indirect
-	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
-	github.com/google/go-cmp v0.5.9 // indirect
-	github.com/google/s2a-go v0.1.3 // indirect
-	github.com/googleapis/enterprise-certificate-proxy v0.2.3 // indirect
-	github.com/googleapis/gax-go/v2 v2.8.0 // indirect
-	go.opencensus.io v0.24.0 // indirect
-	golang.org/x/crypto v0.7.0 // indirect
 	golang.org/x/net v0.9.0 // indirect
-	golang.org/x/oauth2 v0.7.0 // indirect
-	golang.org/x/sync v0.1.0 // indirect
 	golang.org/x/sys v0.7.0 // indirect
 	golang.org/x/text v0.9.0 // indirect
-	google.golang.org/api v0.122.0 // indirect
-	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	google.golang.org/protobuf v1.30.0 // indirect
+)
+
+replace (
+	github.com/alexcogojocaru/cloud-computing-project/api => ../../api
+	github.com/alexcogojocaru/cloud-computing-project/pkg => ../../pkg
 )
@@ -2,87 +2,188 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"io"
 	"log"
+	"math"
 	"math/rand"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
-	"cloud.google.com/go/pubsub"
-	"github.com/alexcogojocaru/cloud-computing-project/driver/client/pb"
+	authv1 "github.com/alexcogojocaru/cloud-computing-project/api/auth/v1"
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/compass"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 )
 
 const (
-	PUBSUB_TOPIC    = "rider-streaming"
-	LOWER_LATITUDE  = 47.14239230121294
-	UPPER_LATITUDE  = 47.183312148060274
-	LOWER_LONGITUDE = 27.531191096268163
-	UPPER_LONGITUDE = 27.660349147474353
+	LOWER_LATITUDE     = 47.14239230121294
+	UPPER_LATITUDE     = 47.183312148060274
+	LOWER_LONGITUDE    = 27.531191096268163
+	UPPER_LONGITUDE    = 27.660349147474353
+	POLLING_TIME       = 10 * time.Second
+	MAX_STEP           = 0.002
+	ACCEPT_PROBABILITY = 0.8
 )
 
-type GeolocationCoordinates struct {
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-}
-
-type DriverDetails struct {
-	ID     string                 `json:"id"`
-	Coords GeolocationCoordinates `json:"coords"`
-}
-
 var (
 	DRIVER_SERVICE_ADDR = os.Getenv("DRIVER_SERVICE_ADDR")
+	AUTH_SERVICE_ADDR   = os.Getenv("AUTH_SERVICE_ADDR")
+	// a new account is registered when the credentials are not set
+	DRIVER_USERNAME = os.Getenv("DRIVER_USERNAME")
+	DRIVER_PASSWORD = os.Getenv("DRIVER_PASSWORD")
+	// STANDARD, COMFORT or XL
+	DRIVER_VEHICLE = os.Getenv("DRIVER_VEHICLE")
 )
 
 func main() {
+	if DRIVER_SERVICE_ADDR == "" {
+		DRIVER_SERVICE_ADDR = "localhost:8081"
+	}
+	if AUTH_SERVICE_ADDR == "" {
+		AUTH_SERVICE_ADDR = "localhost:8083"
+	}
+	if DRIVER_USERNAME == "" {
+		DRIVER_USERNAME = uuid.New().String()
+		DRIVER_PASSWORD = uuid.New().String()
+	}
+
+	vehicle, ok := driverv1.VehicleType_value[DRIVER_VEHICLE]
+	if DRIVER_VEHICLE != "" && !ok {
+		log.Fatalf("unknown vehicle type %s", DRIVER_VEHICLE)
+	}
+
 	ctx := context.Background()
-	pubsubClient, err := pubsub.NewClient(ctx, "cloudcomputing-386413")
+
+	authConn, err := grpc.Dial(AUTH_SERVICE_ADDR, grpc.WithInsecure())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer authConn.Close()
+
+	tokens, err := login(ctx, authv1.NewAuthClient(authConn), DRIVER_USERNAME, DRIVER_PASSWORD)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer pubsubClient.Close()
 
-	conn, err := grpc.Dial("localhost:8081", grpc.WithInsecure())
+	conn, err := grpc.Dial(DRIVER_SERVICE_ADDR, grpc.WithInsecure(), grpc.WithPerRPCCredentials(tokens))
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer conn.Close()
 
-	client := pb.NewDriverClient(conn)
-	drivername := uuid.New().String()
+	client := driverv1.NewDriverClient(conn)
+	drivername := DRIVER_USERNAME
+
+	_, err = client.GoOnline(ctx, &driverv1.ShiftRequest{Name: drivername, Vehicle: driverv1.VehicleType(vehicle)})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// end the shift when the driver closes the app
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		shift, err := client.GoOffline(ctx, &driverv1.ShiftRequest{Name: drivername})
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("name=%s shift ended, online for %s\n", drivername, time.Duration(shift.EndedAt-shift.StartedAt)*time.Millisecond)
+		os.Exit(0)
+	}()
 
-	_, err = client.SetStatus(ctx, &pb.DriverStatusMetadata{
-		Name:   drivername,
-		Status: pb.DriverStatus_FREE,
+	session, err := client.Session(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// grpc streams don't support concurrent sends
+	var sendMu sync.Mutex
+	send := func(req *driverv1.DriverSessionRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return session.Send(req)
+	}
+
+	err = send(&driverv1.DriverSessionRequest{
+		Message: &driverv1.DriverSessionRequest_Start{Start: &driverv1.SessionStart{Name: drivername}},
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	go heartbeat(drivername, send)
+
 	for {
-		latitude := rand.Float64()*(UPPER_LATITUDE-LOWER_LATITUDE) + LOWER_LATITUDE
-		longitude := rand.Float64()*(UPPER_LONGITUDE-LOWER_LONGITUDE) + LOWER_LONGITUDE
+		resp, err := session.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		switch msg := resp.Message.(type) {
+		case *driverv1.DriverSessionResponse_Offer:
+			decision := driverv1.OfferDecision_DECLINE
+			if rand.Float64() < ACCEPT_PROBABILITY {
+				decision = driverv1.OfferDecision_ACCEPT
+			}
+
+			log.Printf("name=%s offer=%s ride=%s decision=%s\n", drivername, msg.Offer.OfferId, msg.Offer.RideId, decision)
 
-		details, _ := json.Marshal(DriverDetails{
-			ID: uuid.New().String(),
-			Coords: GeolocationCoordinates{
+			err := send(&driverv1.DriverSessionRequest{
+				Message: &driverv1.DriverSessionRequest_Reply{Reply: &driverv1.OfferReply{
+					OfferId:  msg.Offer.OfferId,
+					Decision: decision,
+				}},
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		case *driverv1.DriverSessionResponse_Withdrawn:
+			log.Printf("name=%s offer=%s withdrawn\n", drivername, msg.Withdrawn.OfferId)
+		}
+	}
+}
+
+// heartbeat sends the driver's location every POLLING_TIME, the driver wanders around the city
+func heartbeat(drivername string, send func(*driverv1.DriverSessionRequest) error) {
+	latitude := rand.Float64()*(UPPER_LATITUDE-LOWER_LATITUDE) + LOWER_LATITUDE
+	longitude := rand.Float64()*(UPPER_LONGITUDE-LOWER_LONGITUDE) + LOWER_LONGITUDE
+
+	// unknown until the driver moves
+	var heading *float64
+
+	for sequence := uint64(1); ; sequence++ {
+		err := send(&driverv1.DriverSessionRequest{
+			Message: &driverv1.DriverSessionRequest_Heartbeat{Heartbeat: &driverv1.LocationHeartbeat{
 				Latitude:  latitude,
 				Longitude: longitude,
-			},
+				Heading:   heading,
+				Timestamp: time.Now().UnixMilli(),
+				Sequence:  sequence,
+			}},
 		})
-
-		topic := pubsubClient.Topic(PUBSUB_TOPIC)
-		res := topic.Publish(ctx, &pubsub.Message{
-			Data: details,
-		})
-		id, err := res.Get(ctx)
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		log.Printf("name=%s pubsubId=%s\n", drivername, id)
-		time.Sleep(10 * time.Second)
+		log.Printf("name=%s latitude=%f longitude=%f\n", drivername, latitude, longitude)
+		time.Sleep(POLLING_TIME)
+
+		nextLatitude := clamp(latitude+(rand.Float64()*2-1)*MAX_STEP, LOWER_LATITUDE, UPPER_LATITUDE)
+		nextLongitude := clamp(longitude+(rand.Float64()*2-1)*MAX_STEP, LOWER_LONGITUDE, UPPER_LONGITUDE)
+		step := compass.Bearing(latitude, longitude, nextLatitude, nextLongitude)
+		heading = &step
+		latitude, longitude = nextLatitude, nextLongitude
 	}
 }
+
+func clamp(value, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, value))
+}
@@ -0,0 +1,166 @@
+// dlq inspects the driver locations moved to the dead letter topic and replays them.
+//
+//	dlq list [-max 100] [-wait 5s]
+//	dlq replay [-id <message id>,...] [-all] [-max 100] [-wait 5s]
+//
+// It connects to the broker configured the same way as the driver server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+)
+
+const usage = "usage: dlq list|replay [flags]"
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	max := fs.Int("max", 100, "how many dead letters to read at most")
+	wait := fs.Duration("wait", 5*time.Second, "how long to wait for dead letters")
+	ids := fs.String("id", "", "comma separated message ids of the dead letters to replay")
+	all := fs.Bool("all", false, "replay every dead letter read")
+	fs.Parse(os.Args[2:])
+
+	// the same defaults as the driver server, the port is not used
+	cfg, err := config.Load(nil, config.Defaults(8081), config.RequireMessaging)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.Messaging == config.MESSAGING_MEMORY {
+		log.Fatal("the dead letters of a local server are kept in its memory")
+	}
+
+	ctx := context.Background()
+	broker, err := messaging.Open(ctx, cfg, events.Topology())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer broker.Close()
+
+	// pub/sub keeps Receive running until the letters it handed out are acked or nacked
+	receiveCtx, stop := context.WithCancel(ctx)
+	r := newReader(*max)
+	received := make(chan error, 1)
+	go func() {
+		received <- broker.Receive(receiveCtx, events.DriverLocationsDLQ.Subscription, r.handle)
+	}()
+
+	select {
+	case <-r.full:
+	case <-time.After(*wait):
+	case err := <-received:
+		log.Fatal(err)
+	}
+	letters := r.close()
+
+	switch cmd {
+	case "list":
+		for _, m := range letters {
+			printLetter(m)
+			m.Nack()
+		}
+		log.Printf("%d dead letters\n", len(letters))
+	case "replay":
+		if *ids == "" && !*all {
+			log.Fatal("replay needs -id or -all")
+		}
+		replay := make(map[string]bool)
+		for _, id := range strings.Split(*ids, ",") {
+			replay[strings.TrimSpace(id)] = true
+		}
+
+		replayed := 0
+		for _, m := range letters {
+			if !*all && !replay[m.ID] {
+				m.Nack()
+				continue
+			}
+			if _, err := events.DriverLocationsDLQ.Replay(ctx, broker, m); err != nil {
+				log.Printf("id=%s cannot replay: %v\n", m.ID, err)
+				m.Nack()
+				continue
+			}
+			m.Ack()
+			replayed++
+		}
+		log.Printf("%d dead letters replayed\n", replayed)
+	default:
+		log.Fatal(usage)
+	}
+
+	stop()
+	if err := <-received; err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
+
+// reader holds up to max dead letters without acking them, so they can be nacked or acked once handled
+type reader struct {
+	mu      sync.Mutex
+	max     int
+	letters []*messaging.Message
+	seen    map[string]bool
+	closed  bool
+	full    chan struct{}
+}
+
+func newReader(max int) *reader {
+	return &reader{
+		max:  max,
+		seen: make(map[string]bool),
+		full: make(chan struct{}),
+	}
+}
+
+func (r *reader) handle(_ context.Context, m *messaging.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// a nacked letter comes back right away, past max or once closed the letters are given back
+	if r.closed || r.seen[m.ID] || len(r.letters) >= r.max {
+		m.Nack()
+		return
+	}
+	r.seen[m.ID] = true
+	r.letters = append(r.letters, m)
+	if len(r.letters) == r.max {
+		close(r.full)
+	}
+}
+
+// close stops holding new letters and returns the ones held
+func (r *reader) close() []*messaging.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	return r.letters
+}
+
+func printLetter(m *messaging.Message) {
+	fmt.Printf(
+		"id=%s at=%s attempts=%s subscription=%s error=%q\n\t%s\n",
+		m.ID,
+		m.Attributes[events.DEAD_LETTER_TIME],
+		m.Attributes[events.DEAD_LETTER_ATTEMPTS],
+		m.Attributes[events.DEAD_LETTER_SUBSCRIPTION],
+		m.Attributes[events.DEAD_LETTER_ERROR],
+		m.Data,
+	)
+}
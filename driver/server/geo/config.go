@@ -0,0 +1,25 @@
+package geo
+
+import (
+	"fmt"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// LOCATIONS_KEY is the redis geo set of the drivers' locations
+const LOCATIONS_KEY = "drivers/location"
+
+func NewIndex(log *zap.Logger, rdb *redis.Client, cfg *config.Config) (GeoIndex, error) {
+	switch cfg.Driver.GeoIndex {
+	case config.GEO_INDEX_REDIS:
+		log.Info("Using redis geo index")
+		return NewRedisIndex(rdb, LOCATIONS_KEY), nil
+	case config.GEO_INDEX_MEMORY:
+		log.Info("Using in-memory geo index, the locations are lost on restart and not shared with other driver servers")
+		return NewMemoryIndex(), nil
+	default:
+		return nil, fmt.Errorf("unknown geo index %q", cfg.Driver.GeoIndex)
+	}
+}
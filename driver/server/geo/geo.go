@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+var ErrInvalidPolygon = errors.New("Invalid polygon")
+
+// EARTH_RADIUS_KM is the radius redis measures distances with, both indexes agree on the distances
+const EARTH_RADIUS_KM = 6372.797560856
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Location struct {
+	Name string
+	Point
+	// the distance to the searched point in km, set by Nearest
+	Distance float64
+	// orders the upserts of the same name, not set on the searched locations
+	Version Version
+}
+
+// Version orders the locations of a driver by the time they were taken at, then by their sequence number
+type Version struct {
+	At       time.Time
+	Sequence uint64
+}
+
+func (v Version) After(other Version) bool {
+	if !v.At.Equal(other.At) {
+		return v.At.After(other.At)
+	}
+	return v.Sequence > other.Sequence
+}
+
+// GeoIndex finds the drivers around a point or inside an area
+type GeoIndex interface {
+	// Upsert places every location at its point, moving the ones already indexed.
+	// The in-process indexes skip a location older than the indexed one, RedisIndex doesn't keep the versions,
+	// it is written by the same redis script that checks them.
+	Upsert(ctx context.Context, locations ...Location) error
+	// Remove drops the locations, the names not indexed are ignored
+	Remove(ctx context.Context, names ...string) error
+	// Nearest returns up to count locations within radius km of the point, the closest first.
+	// A count of 0 returns all of them.
+	Nearest(ctx context.Context, point Point, radius float64, count int) ([]Location, error)
+	// WithinPolygon returns the locations inside the polygon, in no particular order.
+	// The vertices go around the polygon in either direction, the last one is joined to the first.
+	WithinPolygon(ctx context.Context, polygon []Point) ([]Location, error)
+}
+
+func (p Point) latLng() s2.LatLng {
+	return s2.LatLngFromDegrees(p.Latitude, p.Longitude)
+}
+
+// Distance is the great circle distance between the points in km
+func Distance(a, b Point) float64 {
+	return a.latLng().Distance(b.latLng()).Radians() * EARTH_RADIUS_KM
+}
+
+func kmToAngle(km float64) s1.Angle {
+	return s1.Angle(km / EARTH_RADIUS_KM)
+}
+
+// polygonLoop builds the s2 loop of the polygon, whatever the direction of its vertices
+func polygonLoop(polygon []Point) (*s2.Loop, error) {
+	points := make([]s2.Point, 0, len(polygon))
+	for _, vertex := range polygon {
+		point := s2.PointFromLatLng(vertex.latLng())
+		if len(points) > 0 && point == points[len(points)-1] {
+			continue
+		}
+		points = append(points, point)
+	}
+	// a closed ring repeats the first vertex
+	if len(points) > 1 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+	if len(points) < 3 {
+		return nil, fmt.Errorf("%w: it needs at least 3 distinct vertices", ErrInvalidPolygon)
+	}
+
+	loop := s2.LoopFromPoints(points)
+	// s2 loops hold the area on their left, the smaller side is the polygon
+	loop.Normalize()
+	if err := loop.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPolygon, err)
+	}
+
+	return loop, nil
+}
@@ -0,0 +1,233 @@
+// Package geotest is the conformance suite every geo.GeoIndex passes
+package geotest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+)
+
+// around Iasi, the drivers are about 1km apart
+var (
+	center = geo.Point{Latitude: 47.1585, Longitude: 27.6014}
+	north  = geo.Location{Name: "north", Point: geo.Point{Latitude: 47.1675, Longitude: 27.6014}}
+	east   = geo.Location{Name: "east", Point: geo.Point{Latitude: 47.1585, Longitude: 27.6147}}
+	south  = geo.Location{Name: "south", Point: geo.Point{Latitude: 47.1405, Longitude: 27.6014}}
+	far    = geo.Location{Name: "far", Point: geo.Point{Latitude: 47.6, Longitude: 26.25}}
+)
+
+// haversine is checked against by the suite, independently of the indexes
+func haversine(a, b geo.Point) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat, dLon := lat2-lat1, (b.Longitude-a.Longitude)*math.Pi/180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * geo.EARTH_RADIUS_KM * math.Asin(math.Sqrt(h))
+}
+
+func names(locations []geo.Location) []string {
+	names := make([]string, len(locations))
+	for idx, location := range locations {
+		names[idx] = location.Name
+	}
+	return names
+}
+
+func sorted(locations []geo.Location) []string {
+	names := names(locations)
+	sort.Strings(names)
+	return names
+}
+
+func equal(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for idx := range got {
+		if got[idx] != want[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Run runs the suite, newIndex returns an empty index for every test
+func Run(t *testing.T, newIndex func(t *testing.T) geo.GeoIndex) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, locations ...geo.Location) geo.GeoIndex {
+		t.Helper()
+
+		index := newIndex(t)
+		if err := index.Upsert(ctx, locations...); err != nil {
+			t.Fatal(err)
+		}
+		return index
+	}
+
+	t.Run("NearestClosestFirst", func(t *testing.T) {
+		index := setup(t, far, south, north, east)
+
+		locations, err := index.Nearest(ctx, center, 5, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"north", "east", "south"}) {
+			t.Fatalf("Nearest() = %v", got)
+		}
+
+		for _, location := range locations {
+			want := haversine(center, location.Point)
+			if math.Abs(location.Distance-want) > 0.01 {
+				t.Fatalf("%s is %fkm away, want %fkm", location.Name, location.Distance, want)
+			}
+			if haversine(location.Point, map[string]geo.Point{"north": north.Point, "east": east.Point, "south": south.Point}[location.Name]) > 0.001 {
+				t.Fatalf("%s is at %+v", location.Name, location.Point)
+			}
+		}
+	})
+
+	t.Run("NearestRadiusAndCount", func(t *testing.T) {
+		index := setup(t, far, south, north, east)
+
+		locations, err := index.Nearest(ctx, center, 1.5, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"north", "east"}) {
+			t.Fatalf("Nearest() within 1.5km = %v", got)
+		}
+
+		locations, err = index.Nearest(ctx, center, 500, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"north", "east"}) {
+			t.Fatalf("Nearest() of 2 = %v", got)
+		}
+
+		locations, err = index.Nearest(ctx, center, 500, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"north", "east", "south", "far"}) {
+			t.Fatalf("Nearest() within 500km = %v", got)
+		}
+	})
+
+	t.Run("NearestEmpty", func(t *testing.T) {
+		locations, err := newIndex(t).Nearest(ctx, center, 10, 10)
+		if err != nil || len(locations) != 0 {
+			t.Fatalf("Nearest() on an empty index = %v %v", locations, err)
+		}
+	})
+
+	t.Run("UpsertMoves", func(t *testing.T) {
+		index := setup(t, north, east)
+
+		moved := north
+		moved.Point = far.Point
+		if err := index.Upsert(ctx, moved); err != nil {
+			t.Fatal(err)
+		}
+
+		locations, err := index.Nearest(ctx, center, 5, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"east"}) {
+			t.Fatalf("Nearest() after the move = %v", got)
+		}
+
+		locations, err = index.Nearest(ctx, far.Point, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"north"}) {
+			t.Fatalf("Nearest() around the new point = %v", got)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		index := setup(t, north, east, south)
+
+		if err := index.Remove(ctx, "north", "unknown"); err != nil {
+			t.Fatal(err)
+		}
+		if err := index.Remove(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		locations, err := index.Nearest(ctx, center, 5, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(locations); !equal(got, []string{"east", "south"}) {
+			t.Fatalf("Nearest() after the removal = %v", got)
+		}
+	})
+
+	t.Run("WithinPolygon", func(t *testing.T) {
+		index := setup(t, far, south, north, east)
+
+		// a square around the center with north and east inside, in both directions
+		square := []geo.Point{
+			{Latitude: 47.15, Longitude: 27.59},
+			{Latitude: 47.15, Longitude: 27.62},
+			{Latitude: 47.17, Longitude: 27.62},
+			{Latitude: 47.17, Longitude: 27.59},
+		}
+		reversed := []geo.Point{square[3], square[2], square[1], square[0]}
+		closed := append(append([]geo.Point{}, square...), square[0])
+
+		for _, polygon := range [][]geo.Point{square, reversed, closed} {
+			locations, err := index.WithinPolygon(ctx, polygon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sorted(locations); !equal(got, []string{"east", "north"}) {
+				t.Fatalf("WithinPolygon(%v) = %v", polygon, got)
+			}
+		}
+	})
+
+	t.Run("WithinConcavePolygon", func(t *testing.T) {
+		index := setup(t, far, south, north, east)
+
+		// an L around north and south, the notch leaves east out
+		polygon := []geo.Point{
+			{Latitude: 47.13, Longitude: 27.59},
+			{Latitude: 47.13, Longitude: 27.62},
+			{Latitude: 47.145, Longitude: 27.62},
+			{Latitude: 47.145, Longitude: 27.605},
+			{Latitude: 47.175, Longitude: 27.605},
+			{Latitude: 47.175, Longitude: 27.59},
+		}
+
+		locations, err := index.WithinPolygon(ctx, polygon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sorted(locations); !equal(got, []string{"north", "south"}) {
+			t.Fatalf("WithinPolygon() = %v", got)
+		}
+	})
+
+	t.Run("InvalidPolygon", func(t *testing.T) {
+		index := setup(t, north)
+
+		for _, polygon := range [][]geo.Point{
+			nil,
+			{center, north.Point},
+			{center, north.Point, center},
+		} {
+			if _, err := index.WithinPolygon(ctx, polygon); !errors.Is(err, geo.ErrInvalidPolygon) {
+				t.Fatalf("WithinPolygon(%v) = %v, want %v", polygon, err, geo.ErrInvalidPolygon)
+			}
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package geo
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang/geo/s2"
+)
+
+// MAX_COVERING_CELLS bounds the cells a search area is covered with, fewer cells scan more locations outside the area
+const MAX_COVERING_CELLS = 16
+
+type cellEntry struct {
+	cell s2.CellID
+	name string
+}
+
+// MemoryIndex keeps the locations in the process, sorted by their s2 leaf cell.
+// A search covers its area with a few s2 cells and scans the range of leaf cells of each,
+// it is meant for the tests and for running a single driver server.
+type MemoryIndex struct {
+	mu sync.RWMutex
+	// sorted by cell then by name
+	entries  []cellEntry
+	points   map[string]Point
+	versions map[string]Version
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		points:   make(map[string]Point),
+		versions: make(map[string]Version),
+	}
+}
+
+func (m *MemoryIndex) search(entry cellEntry) int {
+	return sort.Search(len(m.entries), func(idx int) bool {
+		e := m.entries[idx]
+		return e.cell > entry.cell || (e.cell == entry.cell && e.name >= entry.name)
+	})
+}
+
+func (m *MemoryIndex) remove(name string) {
+	point, ok := m.points[name]
+	if !ok {
+		return
+	}
+
+	entry := cellEntry{cell: s2.CellIDFromLatLng(point.latLng()), name: name}
+	idx := m.search(entry)
+	m.entries = append(m.entries[:idx], m.entries[idx+1:]...)
+	delete(m.points, name)
+	delete(m.versions, name)
+}
+
+func (m *MemoryIndex) Upsert(_ context.Context, locations ...Location) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, location := range locations {
+		// a writer that lost the race with a later location of the driver
+		if indexed, ok := m.versions[location.Name]; ok && indexed.After(location.Version) {
+			continue
+		}
+		m.remove(location.Name)
+
+		entry := cellEntry{cell: s2.CellIDFromLatLng(location.latLng()), name: location.Name}
+		idx := m.search(entry)
+		m.entries = append(m.entries, cellEntry{})
+		copy(m.entries[idx+1:], m.entries[idx:])
+		m.entries[idx] = entry
+		m.points[location.Name] = location.Point
+		m.versions[location.Name] = location.Version
+	}
+
+	return nil
+}
+
+func (m *MemoryIndex) Remove(_ context.Context, names ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, name := range names {
+		m.remove(name)
+	}
+
+	return nil
+}
+
+// covered returns the locations in the cells covering the region, some of them may be outside the region
+func (m *MemoryIndex) covered(region s2.Region) []Location {
+	coverer := &s2.RegionCoverer{MaxLevel: s2.MaxLevel, MaxCells: MAX_COVERING_CELLS}
+
+	var locations []Location
+	for _, cell := range coverer.Covering(region) {
+		for idx := m.search(cellEntry{cell: cell.RangeMin()}); idx < len(m.entries) && m.entries[idx].cell <= cell.RangeMax(); idx++ {
+			name := m.entries[idx].name
+			locations = append(locations, Location{Name: name, Point: m.points[name]})
+		}
+	}
+
+	return locations
+}
+
+func (m *MemoryIndex) Nearest(_ context.Context, point Point, radius float64, count int) ([]Location, error) {
+	m.mu.RLock()
+	candidates := m.covered(s2.CapFromCenterAngle(s2.PointFromLatLng(point.latLng()), kmToAngle(radius)))
+	m.mu.RUnlock()
+
+	locations := candidates[:0]
+	for _, location := range candidates {
+		location.Distance = Distance(point, location.Point)
+		if location.Distance <= radius {
+			locations = append(locations, location)
+		}
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Distance != locations[j].Distance {
+			return locations[i].Distance < locations[j].Distance
+		}
+		return locations[i].Name < locations[j].Name
+	})
+	if count > 0 && len(locations) > count {
+		locations = locations[:count]
+	}
+
+	return locations, nil
+}
+
+func (m *MemoryIndex) WithinPolygon(_ context.Context, polygon []Point) ([]Location, error) {
+	loop, err := polygonLoop(polygon)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	candidates := m.covered(loop)
+	m.mu.RUnlock()
+
+	locations := candidates[:0]
+	for _, location := range candidates {
+		if loop.ContainsPoint(s2.PointFromLatLng(location.latLng())) {
+			locations = append(locations, location)
+		}
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,41 @@
+package geo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo/geotest"
+)
+
+func TestMemoryIndex(t *testing.T) {
+	geotest.Run(t, func(t *testing.T) geo.GeoIndex {
+		return geo.NewMemoryIndex()
+	})
+}
+
+func TestMemoryIndexKeepsTheLatestVersion(t *testing.T) {
+	ctx := context.Background()
+	index := geo.NewMemoryIndex()
+	now := time.Now()
+
+	first := geo.Location{Name: "driver", Point: geo.Point{Latitude: 47.16, Longitude: 27.59}, Version: geo.Version{At: now, Sequence: 2}}
+	older := geo.Location{Name: "driver", Point: geo.Point{Latitude: 10, Longitude: 10}, Version: geo.Version{At: now, Sequence: 1}}
+	if err := index.Upsert(ctx, first, older); err != nil {
+		t.Fatal(err)
+	}
+
+	if locations, _ := index.Nearest(ctx, first.Point, 1, 0); len(locations) != 1 {
+		t.Fatalf("Nearest() = %v, want the driver at the latest location", locations)
+	}
+
+	later := older
+	later.Version = geo.Version{At: now.Add(time.Second)}
+	if err := index.Upsert(ctx, later); err != nil {
+		t.Fatal(err)
+	}
+	if locations, _ := index.Nearest(ctx, later.Point, 1, 0); len(locations) != 1 {
+		t.Fatalf("Nearest() = %v, want the driver moved to the later location", locations)
+	}
+}
@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"context"
+	"math"
+
+	"github.com/golang/geo/s2"
+	"github.com/redis/go-redis/v9"
+)
+
+// KM_PER_DEGREE is the length of a degree of latitude, and of longitude at the equator
+const KM_PER_DEGREE = EARTH_RADIUS_KM * math.Pi / 180
+
+// RedisIndex keeps the locations in a redis geo set, it needs redis 6.2 or later for GEOSEARCH
+type RedisIndex struct {
+	rdb redis.Cmdable
+	key string
+}
+
+func NewRedisIndex(rdb redis.Cmdable, key string) *RedisIndex {
+	return &RedisIndex{
+		rdb: rdb,
+		key: key,
+	}
+}
+
+// Key is the geo set the locations are kept in
+func (r *RedisIndex) Key() string {
+	return r.key
+}
+
+func (r *RedisIndex) Upsert(ctx context.Context, locations ...Location) error {
+	if len(locations) == 0 {
+		return nil
+	}
+
+	geoLocations := make([]*redis.GeoLocation, len(locations))
+	for idx, location := range locations {
+		geoLocations[idx] = &redis.GeoLocation{
+			Name:      location.Name,
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+		}
+	}
+
+	return r.rdb.GeoAdd(ctx, r.key, geoLocations...).Err()
+}
+
+func (r *RedisIndex) Remove(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(names))
+	for idx, name := range names {
+		members[idx] = name
+	}
+
+	return r.rdb.ZRem(ctx, r.key, members...).Err()
+}
+
+func (r *RedisIndex) Nearest(ctx context.Context, point Point, radius float64, count int) ([]Location, error) {
+	geoLocations, err := r.rdb.GeoSearchLocation(ctx, r.key, &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude:  point.Longitude,
+			Latitude:   point.Latitude,
+			Radius:     radius,
+			RadiusUnit: "km",
+			Sort:       "ASC",
+			Count:      count,
+		},
+		WithCoord: true,
+		WithDist:  true,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return toLocations(geoLocations), nil
+}
+
+// WithinPolygon searches the box around the polygon and keeps the locations inside the polygon
+func (r *RedisIndex) WithinPolygon(ctx context.Context, polygon []Point) ([]Location, error) {
+	loop, err := polygonLoop(polygon)
+	if err != nil {
+		return nil, err
+	}
+
+	bound := loop.RectBound()
+	center, size := bound.Center(), bound.Size()
+
+	// the width is measured at the equator, wider than needed away from it
+	geoLocations, err := r.rdb.GeoSearchLocation(ctx, r.key, &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude: center.Lng.Degrees(),
+			Latitude:  center.Lat.Degrees(),
+			BoxWidth:  size.Lng.Degrees()*KM_PER_DEGREE + 1,
+			BoxHeight: size.Lat.Degrees()*KM_PER_DEGREE + 1,
+			BoxUnit:   "km",
+		},
+		WithCoord: true,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := toLocations(geoLocations)
+	locations := candidates[:0]
+	for _, location := range candidates {
+		if loop.ContainsPoint(s2.PointFromLatLng(location.latLng())) {
+			locations = append(locations, location)
+		}
+	}
+
+	return locations, nil
+}
+
+func toLocations(geoLocations []redis.GeoLocation) []Location {
+	locations := make([]Location, len(geoLocations))
+	for idx, geoLocation := range geoLocations {
+		locations[idx] = Location{
+			Name: geoLocation.Name,
+			Point: Point{
+				Latitude:  geoLocation.Latitude,
+				Longitude: geoLocation.Longitude,
+			},
+			Distance: geoLocation.Dist,
+		}
+	}
+	return locations
+}
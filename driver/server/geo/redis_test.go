@@ -0,0 +1,37 @@
+package geo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo/geotest"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisIndex(t *testing.T) {
+	geotest.Run(t, func(t *testing.T) geo.GeoIndex {
+		rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+		return geo.NewRedisIndex(rdb, "drivers/location")
+	})
+}
+
+// miniredis approximates redis, the suite also runs against the redis at REDIS_TEST_ADDR
+func TestRedisIndexOnRedis(t *testing.T) {
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR is not set")
+	}
+
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	defer rdb.Close()
+
+	geotest.Run(t, func(t *testing.T) geo.GeoIndex {
+		key := "geotest/" + t.Name()
+		rdb.Del(context.Background(), key)
+		t.Cleanup(func() { rdb.Del(context.Background(), key) })
+		return geo.NewRedisIndex(rdb, key)
+	})
+}
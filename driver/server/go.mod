@@ -3,7 +3,12 @@ module github.com/alexcogojocaru/cloud-computing-project/driver
 go 1.20
 
 require (
-	cloud.google.com/go/pubsub v1.30.1
+	github.com/alexcogojocaru/cloud-computing-project/api v0.0.0-00010101000000-000000000000
+	github.com/alexcogojocaru/cloud-computing-project/auth v0.0.0-00010101000000-000000000000
+	github.com/alexcogojocaru/cloud-computing-project/pkg v0.0.0-00010101000000-000000000000
+	github.com/alicebob/miniredis/v2 v2.39.0
+	github.com/golang/geo v0.0.0-20230421003525-6adc56603217
+	github.com/google/uuid v1.3.0
 	github.com/redis/go-redis/v9 v9.0.4
 	go.uber.org/fx v1.19.3
 	go.uber.org/zap v1.24.0
@@ -16,8 +21,10 @@ require (
 	cloud.google.com/go/compute v1.19.0 // indirect
 	cloud.google.com/go/compute/metadata v0.2.3 // indirect
 	cloud.google.com/go/iam v0.13.0 // indirect
+	cloud.google.com/go/pubsub v1.30.1 // indirect
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/golang-jwt/jwt/v5 v5.0.0 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
@@ -25,6 +32,7 @@ require (
 	github.com/googleapis/enterprise-certificate-proxy v0.2.3 // indirect
 	github.com/googleapis/gax-go/v2 v2.8.0 // indirect
 	github.com/stretchr/testify v1.8.2 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	go.opencensus.io v0.24.0 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/dig v1.16.1 // indirect
@@ -38,4 +46,11 @@ require (
 	google.golang.org/api v0.118.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/alexcogojocaru/cloud-computing-project/api => ../../api
+	github.com/alexcogojocaru/cloud-computing-project/auth => ../../auth/server
+	github.com/alexcogojocaru/cloud-computing-project/pkg => ../../pkg
 )
@@ -0,0 +1,328 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/service"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrMissingID  = errors.New("Missing driver id")
+	ErrDuplicate  = errors.New("Location already ingested")
+	ErrSuperseded = errors.New("Location superseded by a later one of the same batch")
+)
+
+const (
+	// MAX_DELIVERY_ATTEMPTS is how many times an invalid location is tried before it goes to the dead letter topic
+	MAX_DELIVERY_ATTEMPTS = 5
+)
+
+// Validate checks a published location taken at the given time before it is cached,
+// with the checks of service.ValidateLocation the heartbeats go through too
+func Validate(location events.DriverLocation, at, now time.Time) error {
+	if location.ID == "" {
+		return ErrMissingID
+	}
+	return service.ValidateLocation(location.Coords.Latitude, location.Coords.Longitude, location.Heading, at, now)
+}
+
+// Timestamp returns when the location was taken, the publish time stands in for it on the devices that don't send it
+func Timestamp(d *events.Delivery[events.DriverLocation]) time.Time {
+	if !d.Event.Timestamp.IsZero() {
+		return d.Event.Timestamp
+	}
+	if !d.Envelope.Time.IsZero() {
+		return d.Envelope.Time
+	}
+	return d.PublishTime
+}
+
+func seenKey(id string) string {
+	return fmt.Sprintf("drivers/ingest/%s", id)
+}
+
+// eventID identifies a location across redeliveries and across the retried publishes of a device
+func eventID(d *events.Delivery[events.DriverLocation]) string {
+	if d.Envelope.ID != "" {
+		return d.Envelope.ID
+	}
+	return d.ID
+}
+
+// pending is a valid location waiting for its batch to be written
+type pending struct {
+	delivery *events.Delivery[events.DriverLocation]
+	at       time.Time
+}
+
+// after reports whether the location was taken after the other one of the same driver
+func (p pending) after(other pending) bool {
+	if !p.at.Equal(other.at) {
+		return p.at.After(other.at)
+	}
+	return p.delivery.Event.Sequence > other.delivery.Event.Sequence
+}
+
+// dropped reports whether the location was handled without being cached
+func dropped(err error) bool {
+	return errors.Is(err, ErrDuplicate) || errors.Is(err, ErrSuperseded) || errors.Is(err, service.ErrStaleLocation)
+}
+
+// Ingester caches the locations published by the drivers' devices.
+// The locations are written in batches, a delivery is acked or nacked only once its batch is written.
+type Ingester struct {
+	log       *zap.Logger
+	rdb       *redis.Client
+	index     geo.GeoIndex
+	broker    *service.LocationBroker
+	publisher messaging.Publisher
+	// how long the ingested locations are remembered to drop their redeliveries
+	dedupWindow   time.Duration
+	batchSize     int
+	flushInterval time.Duration
+
+	mu    sync.Mutex
+	batch []pending
+	// signaled when the batch is full
+	full chan struct{}
+}
+
+func NewIngester(
+	lc fx.Lifecycle,
+	log *zap.Logger,
+	rdb *redis.Client,
+	index geo.GeoIndex,
+	broker *service.LocationBroker,
+	publisher messaging.Publisher,
+	cfg *config.Config,
+) *Ingester {
+	i := &Ingester{
+		log:           log,
+		rdb:           rdb,
+		index:         index,
+		broker:        broker,
+		publisher:     publisher,
+		dedupWindow:   cfg.Driver.DedupWindow,
+		batchSize:     cfg.Driver.IngestBatchSize,
+		flushInterval: cfg.Driver.IngestFlushInterval,
+		full:          make(chan struct{}, 1),
+	}
+	if i.batchSize < 1 {
+		i.batchSize = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			log.Info("Starting location ingest...", zap.Int("batchSize", i.batchSize), zap.Duration("flushInterval", i.flushInterval))
+			go i.run(ctx, done)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			// the last batch is written before the broker is closed
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+
+	return i
+}
+
+// Handle queues a received location for the next batch. The ones that can't be decoded or are invalid are settled right away.
+func (i *Ingester) Handle(ctx context.Context, d *events.Delivery[events.DriverLocation], err error) {
+	at := Timestamp(d)
+	if err == nil {
+		err = Validate(d.Event, at, time.Now())
+	}
+	if err != nil {
+		i.settle(ctx, d, err, true)
+		return
+	}
+
+	i.mu.Lock()
+	i.batch = append(i.batch, pending{delivery: d, at: at})
+	full := len(i.batch) >= i.batchSize
+	i.mu.Unlock()
+
+	if full {
+		select {
+		case i.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (i *Ingester) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(i.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			i.Flush(context.Background())
+			return
+		case <-ticker.C:
+		case <-i.full:
+		}
+		i.Flush(ctx)
+	}
+}
+
+// Flush writes the queued locations and settles their deliveries
+func (i *Ingester) Flush(ctx context.Context) {
+	i.mu.Lock()
+	batch := i.batch
+	i.batch = nil
+	i.mu.Unlock()
+
+	for len(batch) > 0 {
+		size := i.batchSize
+		if size > len(batch) {
+			size = len(batch)
+		}
+
+		errs := i.write(ctx, batch[:size])
+		for idx, p := range batch[:size] {
+			i.settle(ctx, p.delivery, errs[idx], false)
+		}
+		batch = batch[size:]
+	}
+}
+
+// write caches the latest location of every driver in the batch, the others are superseded by it.
+// The error of every location is at its index.
+func (i *Ingester) write(ctx context.Context, batch []pending) []error {
+	errs := make([]error, len(batch))
+
+	latest := make(map[string]int)
+	for idx, p := range batch {
+		last, ok := latest[p.delivery.Event.ID]
+		if !ok || p.after(batch[last]) {
+			latest[p.delivery.Event.ID] = idx
+		}
+	}
+
+	// claimed before they are cached so concurrent redeliveries are cached once
+	pipe := i.rdb.Pipeline()
+	claims := make(map[int]*redis.BoolCmd, len(latest))
+	for _, idx := range latest {
+		d := batch[idx].delivery
+		claims[idx] = pipe.SetNX(ctx, seenKey(eventID(d)), d.ID, i.dedupWindow)
+	}
+	pipe.Exec(ctx)
+
+	var (
+		updates []service.LocationUpdate
+		written []int
+	)
+	for idx, claim := range claims {
+		claimed, err := claim.Result()
+		switch {
+		case err != nil:
+			errs[idx] = err
+		case !claimed:
+			errs[idx] = ErrDuplicate
+		default:
+			event := batch[idx].delivery.Event
+			updates = append(updates, service.LocationUpdate{
+				Location: &driverv1.DriverLocation{
+					Name:      event.ID,
+					Latitude:  event.Coords.Latitude,
+					Longitude: event.Coords.Longitude,
+					Heading:   event.Heading,
+				},
+				At:       batch[idx].at,
+				Sequence: event.Sequence,
+			})
+			written = append(written, idx)
+		}
+	}
+
+	if len(updates) > 0 {
+		pipe := i.rdb.Pipeline()
+		for n, err := range service.UpdateLocations(ctx, i.rdb, i.index, i.broker, updates) {
+			idx := written[n]
+			errs[idx] = err
+			// let the retry through
+			if err != nil && !dropped(err) {
+				pipe.Del(context.Background(), seenKey(eventID(batch[idx].delivery)))
+			}
+		}
+		pipe.Exec(context.Background())
+	}
+
+	i.log.Debug("Wrote locations", zap.Int("batch", len(batch)), zap.Int("written", len(updates)))
+
+	// a superseded location is settled like the one superseding it, it is retried when that one is
+	for idx, p := range batch {
+		last := latest[p.delivery.Event.ID]
+		if idx == last {
+			continue
+		}
+		if errs[last] == nil || dropped(errs[last]) {
+			errs[idx] = ErrSuperseded
+		} else {
+			errs[idx] = errs[last]
+		}
+	}
+
+	return errs
+}
+
+// settle acks a cached or dropped location and nacks a failed one, the broker delivers it again after a backoff.
+// An invalid location is moved to the dead letter topic after MAX_DELIVERY_ATTEMPTS,
+// one that failed to be written, e.g. while redis is down, is retried until it goes through.
+func (i *Ingester) settle(ctx context.Context, d *events.Delivery[events.DriverLocation], err error, invalid bool) {
+	switch {
+	case err == nil:
+		d.Ack()
+		return
+	case dropped(err):
+		i.log.Debug("Dropped the location", zap.String("msgID", d.ID), zap.String("driverid", d.Event.ID), zap.Error(err))
+		d.Ack()
+		return
+	}
+
+	log := i.log.With(
+		zap.String("msgID", d.ID),
+		zap.String("driverid", d.Event.ID),
+		zap.Int("attempt", d.DeliveryAttempt),
+		zap.Error(err),
+	)
+
+	if !invalid || d.DeliveryAttempt < MAX_DELIVERY_ATTEMPTS {
+		log.Warn("Cannot ingest the location, retrying")
+		d.Nack()
+		return
+	}
+
+	id, dlqErr := events.DriverLocationsDLQ.Send(ctx, i.publisher, events.DriverLocations.Subscription, d.Message, err)
+	if dlqErr != nil {
+		log.Error("Cannot move the location to the dead letter topic", zap.NamedError("dlqError", dlqErr))
+		d.Nack()
+		return
+	}
+
+	log.Error("Moved the location to the dead letter topic", zap.String("deadLetterID", id))
+	d.Ack()
+}
@@ -0,0 +1,430 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/service"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+)
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	valid := events.DriverLocation{ID: "driver-1", Coords: events.Coords{Latitude: 47.16, Longitude: 27.59}}
+
+	tests := []struct {
+		name     string
+		location func(events.DriverLocation) events.DriverLocation
+		at       time.Time
+		want     error
+	}{
+		{"valid", func(l events.DriverLocation) events.DriverLocation { return l }, now, nil},
+		{"missing id", func(l events.DriverLocation) events.DriverLocation { l.ID = ""; return l }, now, ErrMissingID},
+		{"latitude", func(l events.DriverLocation) events.DriverLocation { l.Coords.Latitude = 91; return l }, now, service.ErrLatitudeOutOfRange},
+		{"nan latitude", func(l events.DriverLocation) events.DriverLocation { l.Coords.Latitude = math.NaN(); return l }, now, service.ErrLatitudeOutOfRange},
+		{"longitude", func(l events.DriverLocation) events.DriverLocation { l.Coords.Longitude = -180.5; return l }, now, service.ErrLongitudeOutOfRange},
+		{"heading", func(l events.DriverLocation) events.DriverLocation { heading := 360.0; l.Heading = &heading; return l }, now, service.ErrHeadingOutOfRange},
+		{"missing timestamp", func(l events.DriverLocation) events.DriverLocation { return l }, time.Time{}, service.ErrMissingTimestamp},
+		{"future timestamp", func(l events.DriverLocation) events.DriverLocation { return l }, now.Add(time.Hour), service.ErrFutureTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.location(valid), tt.at, now); !errors.Is(err, tt.want) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+type testEnv struct {
+	mr       *miniredis.Miniredis
+	rdb      *redis.Client
+	broker   *messaging.MemoryBroker
+	ingester *Ingester
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	broker := messaging.NewMemory(events.Topology())
+	cfg := config.Defaults(8081)
+	lc := fxtest.NewLifecycle(t)
+	ingester := NewIngester(lc, zap.NewNop(), rdb, geo.NewRedisIndex(rdb, geo.LOCATIONS_KEY), service.NewLocationBroker(zap.NewNop(), rdb), broker, &cfg)
+	lc.RequireStart()
+	t.Cleanup(func() { lc.RequireStop() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go events.Subscribe(ctx, broker, events.DriverLocations, ingester.Handle)
+
+	return &testEnv{mr: mr, rdb: rdb, broker: broker, ingester: ingester}
+}
+
+// cached waits until the driver's location is cached
+func (env *testEnv) cached(t *testing.T, name string) bool {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		positions, err := env.rdb.GeoPos(context.Background(), "drivers/location", name).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if positions[0] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHandleCachesValidLocations(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := events.Publish(context.Background(), env.broker, events.DriverLocations, events.DriverLocation{
+		ID:        "driver-1",
+		Coords:    events.Coords{Latitude: 47.16, Longitude: 27.59},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !env.cached(t, "driver-1") {
+		t.Fatal("the location was not cached")
+	}
+}
+
+func TestHandleMovesPoisonMessagesToTheDeadLetterTopic(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := env.broker.Publish(ctx, events.DriverLocations.Name, []byte(`{"coords":{"latitude":0,"longitude":0}}`), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	letters := make(chan *messaging.Message, 1)
+	go env.broker.Receive(ctx, events.DriverLocationsDLQ.Subscription, func(ctx context.Context, m *messaging.Message) {
+		m.Ack()
+		letters <- m
+	})
+
+	select {
+	case m := <-letters:
+		if m.Attributes[events.DEAD_LETTER_ERROR] != ErrMissingID.Error() {
+			t.Fatalf("dead letter error = %q", m.Attributes[events.DEAD_LETTER_ERROR])
+		}
+		if m.Attributes[events.DEAD_LETTER_ATTEMPTS] != "5" {
+			t.Fatalf("dead lettered after %s attempts", m.Attributes[events.DEAD_LETTER_ATTEMPTS])
+		}
+	case <-ctx.Done():
+		t.Fatal("the message was not dead lettered")
+	}
+
+	if n, _ := env.rdb.ZCard(context.Background(), "drivers/location").Result(); n != 0 {
+		t.Fatalf("%d locations cached from an invalid message", n)
+	}
+}
+
+func TestHandleRetriesFailedWritesPastTheDeliveryAttempts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	env.mr.SetError("LOADING redis is loading the dataset in memory")
+	_, err := events.Publish(ctx, env.broker, events.DriverLocations, events.DriverLocation{
+		ID:        "driver-1",
+		Coords:    events.Coords{Latitude: 47.16, Longitude: 27.59},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	letters := make(chan *messaging.Message, 1)
+	go env.broker.Receive(ctx, events.DriverLocationsDLQ.Subscription, func(ctx context.Context, m *messaging.Message) {
+		m.Ack()
+		letters <- m
+	})
+
+	// long enough for more than MAX_DELIVERY_ATTEMPTS deliveries with the flushes and the backoff of the broker
+	select {
+	case m := <-letters:
+		t.Fatalf("the location was dead lettered while redis was down: %s", m.Attributes[events.DEAD_LETTER_ERROR])
+	case <-time.After(time.Second):
+	}
+
+	env.mr.SetError("")
+	if !env.cached(t, "driver-1") {
+		t.Fatal("the location was not cached once redis was back")
+	}
+}
+
+func TestHandleDropsOlderLocations(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	now := time.Now()
+
+	for _, location := range []events.DriverLocation{
+		{ID: "driver-1", Coords: events.Coords{Latitude: 47.16, Longitude: 27.59}, Timestamp: now},
+		{ID: "driver-1", Coords: events.Coords{Latitude: 10, Longitude: 10}, Timestamp: now.Add(-time.Second)},
+	} {
+		if _, err := events.Publish(ctx, env.broker, events.DriverLocations, location); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	letters := make(chan *messaging.Message, 1)
+	go env.broker.Receive(ctx, events.DriverLocationsDLQ.Subscription, func(ctx context.Context, m *messaging.Message) {
+		m.Ack()
+		letters <- m
+	})
+
+	// the older location is dropped, not retried until it is dead lettered
+	select {
+	case m := <-letters:
+		t.Fatalf("the older location was dead lettered: %s", m.Attributes[events.DEAD_LETTER_ERROR])
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	positions, err := env.rdb.GeoPos(ctx, "drivers/location", "driver-1").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if positions[0] == nil || math.Abs(positions[0].Latitude-47.16) > 0.001 {
+		t.Fatalf("the driver is at %+v", positions[0])
+	}
+}
+
+func delivery(t *testing.T, msgID string, data []byte) *events.Delivery[events.DriverLocation] {
+	t.Helper()
+
+	event, envelope, err := events.DriverLocations.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &events.Delivery[events.DriverLocation]{
+		Message:  &messaging.Message{ID: msgID, Data: data, PublishTime: time.Now()},
+		Envelope: envelope,
+		Event:    event,
+	}
+}
+
+// write writes the deliveries as one batch
+func (env *testEnv) write(ctx context.Context, deliveries ...*events.Delivery[events.DriverLocation]) []error {
+	batch := make([]pending, len(deliveries))
+	for idx, d := range deliveries {
+		batch[idx] = pending{delivery: d, at: Timestamp(d)}
+	}
+	return env.ingester.write(ctx, batch)
+}
+
+func TestWriteOrdersBySequence(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	at := time.Now()
+
+	for _, tt := range []struct {
+		sequence uint64
+		want     error
+	}{
+		{2, nil},
+		{1, service.ErrStaleLocation},
+		{2, service.ErrStaleLocation},
+		{3, nil},
+	} {
+		data, err := events.DriverLocations.Encode(events.DriverLocation{ID: "driver-1", Timestamp: at, Sequence: tt.sequence})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := env.write(ctx, delivery(t, "1", data))[0]; !errors.Is(err, tt.want) {
+			t.Fatalf("ingest of sequence %d = %v, want %v", tt.sequence, err, tt.want)
+		}
+	}
+}
+
+func TestWriteDropsRedeliveries(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	data, err := events.DriverLocations.Encode(events.DriverLocation{ID: "driver-1", Timestamp: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.write(ctx, delivery(t, "1", data))[0]; err != nil {
+		t.Fatal(err)
+	}
+
+	// a redelivery, or the same event published again by the device
+	for _, msgID := range []string{"1", "2"} {
+		if err := env.write(ctx, delivery(t, msgID, data))[0]; !errors.Is(err, ErrDuplicate) {
+			t.Fatalf("ingest of a redelivery = %v, want %v", err, ErrDuplicate)
+		}
+	}
+
+	// a legacy payload has no envelope id, its message id is used instead
+	legacy := []byte(`{"id":"driver-2","coords":{"latitude":1,"longitude":1}}`)
+	if err := env.write(ctx, delivery(t, "3", legacy))[0]; err != nil {
+		t.Fatal(err)
+	}
+	if err := env.write(ctx, delivery(t, "3", legacy))[0]; !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("ingest of a legacy redelivery = %v, want %v", err, ErrDuplicate)
+	}
+}
+
+func TestWriteCollapsesEveryDriverToTheLatestLocation(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	at := time.Now()
+
+	var deliveries []*events.Delivery[events.DriverLocation]
+	for idx, location := range []events.DriverLocation{
+		{ID: "driver-1", Coords: events.Coords{Latitude: 1, Longitude: 1}, Timestamp: at.Add(-time.Second)},
+		{ID: "driver-1", Coords: events.Coords{Latitude: 3, Longitude: 3}, Timestamp: at, Sequence: 2},
+		{ID: "driver-2", Coords: events.Coords{Latitude: 5, Longitude: 5}, Timestamp: at},
+		{ID: "driver-1", Coords: events.Coords{Latitude: 2, Longitude: 2}, Timestamp: at, Sequence: 1},
+	} {
+		data, err := events.DriverLocations.Encode(location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deliveries = append(deliveries, delivery(t, strconv.Itoa(idx), data))
+	}
+
+	errs := env.write(ctx, deliveries...)
+	for idx, want := range []error{ErrSuperseded, nil, nil, ErrSuperseded} {
+		if !errors.Is(errs[idx], want) {
+			t.Fatalf("location %d = %v, want %v", idx, errs[idx], want)
+		}
+	}
+
+	positions, err := env.rdb.GeoPos(ctx, "drivers/location", "driver-1", "driver-2").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(positions[0].Latitude-3) > 0.001 || math.Abs(positions[1].Latitude-5) > 0.001 {
+		t.Fatalf("the drivers are at %+v %+v", positions[0], positions[1])
+	}
+}
+
+func TestHandleFlushesFullBatches(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	broker := messaging.NewMemory(events.Topology())
+
+	// only a full batch is written before the test ends
+	cfg := config.Defaults(8081)
+	cfg.Driver.IngestBatchSize = 3
+	cfg.Driver.IngestFlushInterval = time.Hour
+
+	lc := fxtest.NewLifecycle(t)
+	ingester := NewIngester(lc, zap.NewNop(), rdb, geo.NewRedisIndex(rdb, geo.LOCATIONS_KEY), service.NewLocationBroker(zap.NewNop(), rdb), broker, &cfg)
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go events.Subscribe(ctx, broker, events.DriverLocations, ingester.Handle)
+
+	env := &testEnv{rdb: rdb, broker: broker, ingester: ingester}
+	publish := func(name string) {
+		_, err := events.Publish(ctx, broker, events.DriverLocations, events.DriverLocation{ID: name, Timestamp: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	publish("driver-1")
+	publish("driver-2")
+	time.Sleep(50 * time.Millisecond)
+	if n, _ := rdb.ZCard(ctx, "drivers/location").Result(); n != 0 {
+		t.Fatalf("%d locations written before the batch was full", n)
+	}
+
+	publish("driver-3")
+	if !env.cached(t, "driver-3") {
+		t.Fatal("the full batch was not written")
+	}
+}
+
+// benchRedis is the redis at REDIS_TEST_ADDR, emptied first, or miniredis.
+// miniredis runs the scripts far slower than redis, the round trips saved by the batches barely show on it.
+func benchRedis(b *testing.B) *redis.Client {
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		return redis.NewClient(&redis.Options{Addr: miniredis.RunT(b).Addr()})
+	}
+
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	if err := rdb.FlushDB(context.Background()).Err(); err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { rdb.Close() })
+	return rdb
+}
+
+func BenchmarkWrite(b *testing.B) {
+	rdb := benchRedis(b)
+	cfg := config.Defaults(8081)
+	lc := fxtest.NewLifecycle(b)
+	ingester := NewIngester(lc, zap.NewNop(), rdb, geo.NewRedisIndex(rdb, geo.LOCATIONS_KEY), service.NewLocationBroker(zap.NewNop(), rdb), messaging.NewMemory(events.Topology()), &cfg)
+
+	start := time.Now()
+
+	// with few drivers most of every batch is collapsed
+	for _, bench := range []struct{ size, drivers int }{
+		{1, 1000},
+		{10, 1000},
+		{100, 1000},
+		{500, 1000},
+		{500, 10},
+	} {
+		size, drivers := bench.size, bench.drivers
+		b.Run(fmt.Sprintf("batch=%d/drivers=%d", size, drivers), func(b *testing.B) {
+			ctx := context.Background()
+			batch := make([]pending, 0, size)
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				at := start.Add(time.Duration(n) * time.Millisecond)
+				data, _ := events.DriverLocations.Encode(events.DriverLocation{
+					ID:        fmt.Sprintf("driver-%d", n%drivers),
+					Coords:    events.Coords{Latitude: 47.16, Longitude: 27.59},
+					Timestamp: at,
+					Sequence:  uint64(n),
+				})
+				event, envelope, _ := events.DriverLocations.Decode(data)
+				batch = append(batch, pending{
+					delivery: &events.Delivery[events.DriverLocation]{Message: &messaging.Message{ID: strconv.Itoa(n)}, Envelope: envelope, Event: event},
+					at:       at,
+				})
+
+				if len(batch) == size || n == b.N-1 {
+					for _, err := range ingester.write(ctx, batch) {
+						if err != nil && !dropped(err) {
+							b.Fatal(err)
+						}
+					}
+					batch = batch[:0]
+				}
+			}
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "locations/s")
+		})
+	}
+}
@@ -1,21 +1,44 @@
 package main
 
 import (
+	"os"
+
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/ingest"
 	"github.com/alexcogojocaru/cloud-computing-project/driver/service"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/rdb"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/zap"
 )
 
+func NewConfig() (*config.Config, error) {
+	return config.Load(os.Args[1:], config.Defaults(8081), config.RequireRedis, config.RequireMessaging, config.RequirePresence, config.RequireIngest, config.RequireAuth)
+}
+
 func main() {
 	fx.New(
 		fx.WithLogger(func(log *zap.Logger) fxevent.Logger {
 			return &fxevent.ZapLogger{Logger: log}
 		}),
 		fx.Provide(
+			NewConfig,
 			NewDriverService,
-			NewRedisClient,
+			rdb.New,
+			events.Topology,
+			messaging.NewBroker,
+			messaging.AsPublisher,
+			messaging.AsSubscriber,
+			ingest.NewIngester,
+			geo.NewIndex,
 			service.NewDriverGrpcService,
+			service.NewLocationBroker,
+			service.NewPresence,
+			token.NewManagerFromConfig,
 			zap.NewExample,
 		), fx.Invoke(
 			func(*DriverService) {},
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// LocationBroker fans out the location updates of every driver to the streams watching them.
+// It goes through redis pub/sub so that watchers get updates received by any driver server instance.
+type LocationBroker struct {
+	log *zap.Logger
+	rdb *redis.Client
+}
+
+func NewLocationBroker(log *zap.Logger, rdb *redis.Client) *LocationBroker {
+	return &LocationBroker{
+		log: log,
+		rdb: rdb,
+	}
+}
+
+func locationChannel(name string) string {
+	return fmt.Sprintf("drivers/location/%s", name)
+}
+
+func (b *LocationBroker) Publish(ctx context.Context, location *driverv1.DriverLocation) error {
+	return b.publish(ctx, b.rdb, location).Err()
+}
+
+// publish queues the location on a pipeline
+func (b *LocationBroker) publish(ctx context.Context, rdb redis.Cmdable, location *driverv1.DriverLocation) *redis.IntCmd {
+	data, err := proto.Marshal(location)
+	if err != nil {
+		cmd := redis.NewIntCmd(ctx)
+		cmd.SetErr(err)
+		return cmd
+	}
+
+	return rdb.Publish(ctx, locationChannel(location.Name), data)
+}
+
+// Subscribe streams the driver's locations until ctx is done
+func (b *LocationBroker) Subscribe(ctx context.Context, name string) <-chan *driverv1.DriverLocation {
+	sub := b.rdb.Subscribe(ctx, locationChannel(name))
+	locations := make(chan *driverv1.DriverLocation)
+
+	go func() {
+		defer close(locations)
+		defer sub.Close()
+
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var location driverv1.DriverLocation
+				if err := proto.Unmarshal([]byte(msg.Payload), &location); err != nil {
+					b.log.Error("Cannot decode the driver's location", zap.String("driver", name), zap.Error(err))
+					continue
+				}
+
+				select {
+				case locations <- &location:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return locations
+}
@@ -2,42 +2,166 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/alexcogojocaru/cloud-computing-project/driver/pb"
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// MAX_CLOCK_SKEW is how far ahead of the server the clock of a device may be
+const MAX_CLOCK_SKEW = time.Minute
+
+var (
+	ErrNoDriverAvailable   = status.Error(codes.NotFound, "no driver available")
+	ErrStaleLocation       = errors.New("Location not newer than the last one of the driver")
+	ErrLatitudeOutOfRange  = errors.New("Latitude out of range")
+	ErrLongitudeOutOfRange = errors.New("Longitude out of range")
+	ErrHeadingOutOfRange   = errors.New("Heading out of range")
+	ErrMissingTimestamp    = errors.New("Missing timestamp")
+	ErrFutureTimestamp     = errors.New("Timestamp in the future")
+)
+
+// ValidateLocation checks a location taken at the given time before it is cached, the heading is optional
+func ValidateLocation(latitude, longitude float64, heading *float64, at, now time.Time) error {
+	// written so that NaN is out of range too
+	if !(latitude >= -90 && latitude <= 90) {
+		return fmt.Errorf("%w: %v", ErrLatitudeOutOfRange, latitude)
+	}
+	if !(longitude >= -180 && longitude <= 180) {
+		return fmt.Errorf("%w: %v", ErrLongitudeOutOfRange, longitude)
+	}
+	if heading != nil && !(*heading >= 0 && *heading < 360) {
+		return fmt.Errorf("%w: %v", ErrHeadingOutOfRange, *heading)
+	}
+	if at.IsZero() {
+		return ErrMissingTimestamp
+	}
+	if at.After(now.Add(MAX_CLOCK_SKEW)) {
+		return fmt.Errorf("%w: %s", ErrFutureTimestamp, at.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// LOCATION_TIMES_KEY, LOCATION_SEQUENCES_KEY and LOCATION_HEADINGS_KEY are hashes of the time, in unix milliseconds,
+// of the sequence number and of the heading of every driver's last location
+const (
+	LOCATION_TIMES_KEY     = "drivers/location/times"
+	LOCATION_SEQUENCES_KEY = "drivers/location/sequences"
+	LOCATION_HEADINGS_KEY  = "drivers/location/headings"
+)
+
+// updateLocationScript marks the driver as seen and keeps the time and the heading of the location, unless it is not newer
+// than the stored one. Locations are ordered by time, then by sequence number.
+// With the redis geo index, its geo set is the fifth key and the location is indexed in the same step.
+var updateLocationScript = redis.NewScript(`
+local at = tonumber(ARGV[2])
+local last = tonumber(redis.call('HGET', KEYS[2], ARGV[1]))
+if last and (last > at or (last == at and tonumber(redis.call('HGET', KEYS[3], ARGV[1]) or '0') >= tonumber(ARGV[4]))) then
+	return redis.error_reply('STALE')
+end
+-- first, an invalid location fails before anything is written
+if KEYS[5] then
+	redis.call('GEOADD', KEYS[5], ARGV[7], ARGV[6], ARGV[1])
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+redis.call('HSET', KEYS[3], ARGV[1], ARGV[4])
+if ARGV[5] == '' then
+	redis.call('HDEL', KEYS[4], ARGV[1])
+else
+	redis.call('HSET', KEYS[4], ARGV[1], ARGV[5])
+end
+return 1
+`)
+
+// claimScript marks the driver as BUSY if they are FREE and were seen since the cutoff, in a single redis call
+// so two concurrent reservations can never claim the same driver. It returns the claimed driver's vehicle, nil when not claimed.
+// Only drivers on shift have a status, the ones offline or gone without a status are never claimed.
+var claimScript = redis.NewScript(`
+local seen = tonumber(redis.call('ZSCORE', KEYS[1], ARGV[1]))
+if seen and seen >= tonumber(ARGV[2]) and redis.call('GET', KEYS[2]) == 'FREE' then
+	redis.call('SET', KEYS[2], 'BUSY')
+	return redis.call('HGET', KEYS[3], ARGV[1]) or ''
+end
+return false
+`)
+
+// RESERVE_CANDIDATES is how many of the closest drivers a reservation tries to claim, on top of the excluded ones
+const RESERVE_CANDIDATES = DEFAULT_MAX_RESULTS
+
+// FREE_SINCE_KEY is a hash of the time, in unix milliseconds, every driver last became FREE at
+const FREE_SINCE_KEY = "drivers/free"
+
+// setStatusScript sets the driver's status and keeps when they became FREE,
+// only a driver on shift can become FREE
+var setStatusScript = redis.NewScript(`
+if ARGV[2] == 'FREE' and redis.call('HEXISTS', KEYS[3], ARGV[1]) == 0 then
+	return redis.error_reply('NOT_ONLINE')
+end
+if ARGV[2] == 'FREE' and redis.call('GET', KEYS[2]) ~= 'FREE' then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+end
+redis.call('SET', KEYS[2], ARGV[2])
+return 1
+`)
+
 type DriverGrpcService struct {
-	pb.UnimplementedDriverServer
+	driverv1.UnimplementedDriverServer
 
-	log *zap.Logger
-	rdb *redis.Client
+	log      *zap.Logger
+	rdb      *redis.Client
+	index    geo.GeoIndex
+	broker   *LocationBroker
+	presence *Presence
+	sessions *sessionRegistry
 }
 
 func NewDriverGrpcService(
 	lc fx.Lifecycle,
 	log *zap.Logger,
 	rdb *redis.Client,
+	index geo.GeoIndex,
+	broker *LocationBroker,
+	presence *Presence,
+	tokens *token.Manager,
+	cfg *config.Config,
 ) *DriverGrpcService {
 	d := &DriverGrpcService{
-		log: log,
-		rdb: rdb,
+		log:      log,
+		rdb:      rdb,
+		index:    index,
+		broker:   broker,
+		presence: presence,
+		sessions: newSessionRegistry(),
 	}
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			lis, err := net.Listen("tcp", "0.0.0.0:8081")
+			lis, err := net.Listen("tcp", cfg.Addr())
 			if err != nil {
-				log.Fatal("Cannot listen to port 8081", zap.Error(err))
+				log.Fatal("Cannot listen", zap.String("addr", cfg.Addr()), zap.Error(err))
 			}
 
-			grpcServer := grpc.NewServer()
-			pb.RegisterDriverServer(grpcServer, d)
+			grpcServer := grpc.NewServer(
+				grpc.UnaryInterceptor(interceptor.Unary(tokens, policy)),
+				grpc.StreamInterceptor(interceptor.Stream(tokens, policy)),
+			)
+			driverv1.RegisterDriverServer(grpcServer, d)
 
 			go func() {
 				log.Info("Starting grpc service...")
@@ -56,93 +180,282 @@ func NewDriverGrpcService(
 	return d
 }
 
-func (d *DriverGrpcService) GetClosest(ctx context.Context, location *pb.LocationMetadata) (*pb.DriverLocationList, error) {
+func (d *DriverGrpcService) GetClosest(ctx context.Context, req *driverv1.GetClosestRequest) (*driverv1.DriverLocationList, error) {
 	d.log.Info("Received getClosest request", zap.String("method", "GetClosest"))
-	geoLocations, err := GetClosestDriver(d.rdb, location)
+	locations, nextPageToken, err := GetClosestDriver(ctx, d.index, d.rdb, req, d.presence.Cutoff())
 	if err != nil {
 		return nil, err
 	}
 
-	driverLocations := &pb.DriverLocationList{
-		Locations: make([]*pb.DriverLocation, len(geoLocations)),
-	}
-
-	for idx, geoLocation := range geoLocations {
-		driverLocations.Locations[idx] = &pb.DriverLocation{
-			Name:      geoLocation.Name,
-			Latitude:  geoLocation.Latitude,
-			Longitude: geoLocation.Longitude,
-			Distance:  geoLocation.Dist,
-		}
-	}
-
-	d.log.Info("Found drivers", zap.Int("size", len(driverLocations.Locations)))
+	d.log.Info("Found drivers", zap.Int("size", len(locations)), zap.Bool("lastPage", nextPageToken == ""))
 
-	return driverLocations, nil
+	return &driverv1.DriverLocationList{
+		Locations:     locations,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
-func (d *DriverGrpcService) GetStatus(ctx context.Context, metadata *pb.DriverStatusMetadata) (*pb.DriverStatusMetadata, error) {
+func (d *DriverGrpcService) GetStatus(ctx context.Context, metadata *driverv1.DriverStatusMetadata) (*driverv1.DriverStatusMetadata, error) {
+	if !interceptor.Owns(ctx, metadata.Name) {
+		return nil, interceptor.ErrNotOwner
+	}
+
 	value, err := d.rdb.Get(ctx, metadata.Name).Result()
+	if err == redis.Nil {
+		d.log.Warn("No data in redis for driver", zap.String("driver", metadata.Name))
+		return nil, status.Errorf(codes.NotFound, "unknown driver %s", metadata.Name)
+	}
 	if err != nil {
-		// d.log.Warn("No data in redis for driver", zap.String("driver", metadata.Name))
-		// return &pb.DriverStatusMetadata{
-		// 	Status: pb.DriverStatus_UNKNOWN,
-		// }, err
-
-		// for demo purposes, set the driver's status to FREE
-		d.rdb.Set(ctx, metadata.Name, pb.DriverStatus_FREE.String(), 0*time.Second).Err()
+		return nil, err
 	}
 
-	var status pb.DriverStatus
-	if value == "BUSY" {
-		status = pb.DriverStatus_BUSY
-	} else {
-		status = pb.DriverStatus_FREE
-	}
+	driverStatus := driverv1.DriverStatus(driverv1.DriverStatus_value[value])
 
 	d.log.Info(
 		"GetStatus",
 		zap.String("name", metadata.Name),
 		zap.String("redisValue", value),
-		zap.String("status", status.String()),
+		zap.String("status", driverStatus.String()),
 	)
 
-	return &pb.DriverStatusMetadata{
+	return &driverv1.DriverStatusMetadata{
 		Name:   metadata.Name,
-		Status: status,
+		Status: driverStatus,
 	}, nil
 }
 
-func (d *DriverGrpcService) SetStatus(ctx context.Context, metadata *pb.DriverStatusMetadata) (*pb.Empty, error) {
-	err := d.rdb.Set(ctx, metadata.Name, metadata.Status.String(), 0*time.Second).Err()
+func (d *DriverGrpcService) SetStatus(ctx context.Context, metadata *driverv1.DriverStatusMetadata) (*driverv1.Empty, error) {
+	if !interceptor.Owns(ctx, metadata.Name) {
+		return nil, interceptor.ErrNotOwner
+	}
+
+	err := setStatusScript.Run(ctx, d.rdb, []string{FREE_SINCE_KEY, metadata.Name, ONLINE_KEY}, metadata.Name, metadata.Status.String(), time.Now().UnixMilli()).Err()
+	if err != nil && strings.HasSuffix(err.Error(), "NOT_ONLINE") {
+		return nil, status.Error(codes.FailedPrecondition, "the driver is not online")
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	d.log.Info("SetStatus", zap.String("drivername", metadata.Name), zap.String("status", metadata.Status.String()))
 
-	return &pb.Empty{}, nil
+	return &driverv1.Empty{}, nil
 }
 
-func GetClosestDriver(rdb *redis.Client, location *pb.LocationMetadata) ([]redis.GeoLocation, error) {
-	locations, err := rdb.GeoRadius(
-		context.Background(),
-		"drivers/location",
-		location.Longitude,
-		location.Latitude,
-		&redis.GeoRadiusQuery{
-			Radius:      location.Radius,
-			Unit:        "km",
-			WithGeoHash: true,
-			WithCoord:   true,
-			WithDist:    true,
-			Count:       10,
-			Sort:        "ASC",
-		},
-	).Result()
+func (d *DriverGrpcService) Reserve(ctx context.Context, req *driverv1.ReserveRequest) (*driverv1.DriverLocation, error) {
+	driver, err := ReserveClosestDriver(ctx, d.index, d.rdb, req.Location, req.Exclude, req.Names, d.presence.Cutoff())
+	if err == ErrNoDriverAvailable {
+		d.log.Info("Reserve", zap.String("result", "no driver available"))
+		return nil, ErrNoDriverAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.log.Info("Reserve", zap.String("drivername", driver.Name), zap.Float64("distance", driver.Distance))
+
+	return driver, nil
+}
+
+func (d *DriverGrpcService) Watch(req *driverv1.WatchRequest, stream driverv1.Driver_WatchServer) error {
+	d.log.Info("Watching driver", zap.String("method", "Watch"), zap.String("drivername", req.Name))
+
+	for location := range d.broker.Subscribe(stream.Context(), req.Name) {
+		if err := stream.Send(location); err != nil {
+			return err
+		}
+	}
+
+	return stream.Context().Err()
+}
+
+// LocationUpdate is a driver's location together with the time it was taken at and the device's sequence number
+type LocationUpdate struct {
+	Location *driverv1.DriverLocation
+	At       time.Time
+	Sequence uint64
+}
+
+// heading is the script argument of the location's heading, empty when the device didn't send it
+func heading(location *driverv1.DriverLocation) string {
+	if location.Heading == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*location.Heading, 'f', -1, 64)
+}
+
+// UpdateLocation caches the driver's location taken at the given time, marks them as seen and lets the rides watching them know.
+// It returns ErrStaleLocation when the same or a later location of the driver is already cached.
+func UpdateLocation(ctx context.Context, rdb *redis.Client, index geo.GeoIndex, broker *LocationBroker, location *driverv1.DriverLocation, at time.Time, sequence uint64) error {
+	return UpdateLocations(ctx, rdb, index, broker, []LocationUpdate{{Location: location, At: at, Sequence: sequence}})[0]
+}
+
+// UpdateLocations is UpdateLocation for many locations in two round trips,
+// one to check, mark as seen and index them and one to publish them.
+// The in-process indexes are written in between, they keep the latest location of a driver written concurrently.
+// The error of every update is at its index.
+func UpdateLocations(ctx context.Context, rdb *redis.Client, index geo.GeoIndex, broker *LocationBroker, updates []LocationUpdate) []error {
+	errs := make([]error, len(updates))
+
+	keys := []string{PRESENCE_KEY, LOCATION_TIMES_KEY, LOCATION_SEQUENCES_KEY, LOCATION_HEADINGS_KEY}
+	redisIndex, indexed := index.(*geo.RedisIndex)
+	if indexed {
+		keys = append(keys, redisIndex.Key())
+	}
+
+	pending := make([]int, len(updates))
+	for idx := range updates {
+		pending[idx] = idx
+	}
+
+	// the script is loaded when redis doesn't know it yet and the updates that missed it are run again
+	for attempt := 0; attempt < 2 && len(pending) > 0; attempt++ {
+		pipe := rdb.Pipeline()
+		cmds := make([]*redis.Cmd, len(pending))
+		for n, idx := range pending {
+			update := updates[idx]
+			cmds[n] = updateLocationScript.EvalSha(
+				ctx,
+				pipe,
+				keys,
+				update.Location.Name,
+				update.At.UnixMilli(),
+				time.Now().Unix(),
+				update.Sequence,
+				heading(update.Location),
+				update.Location.Latitude,
+				update.Location.Longitude,
+			)
+		}
+		// the errors are read from every command
+		pipe.Exec(ctx)
+
+		var missed []int
+		for n, idx := range pending {
+			errs[idx] = cmds[n].Err()
+			if errs[idx] != nil && redis.HasErrorPrefix(errs[idx], "NOSCRIPT") {
+				missed = append(missed, idx)
+			}
+		}
+		if len(missed) == 0 {
+			break
+		}
+		if err := updateLocationScript.Load(ctx, rdb).Err(); err != nil {
+			for _, idx := range missed {
+				errs[idx] = err
+			}
+			break
+		}
+		pending = missed
+	}
+
+	var (
+		accepted []int
+		moved    []geo.Location
+	)
+	for idx, err := range errs {
+		switch {
+		case err == nil:
+			location := updates[idx].Location
+			accepted = append(accepted, idx)
+			moved = append(moved, geo.Location{
+				Name:    location.Name,
+				Point:   geo.Point{Latitude: location.Latitude, Longitude: location.Longitude},
+				Version: geo.Version{At: updates[idx].At, Sequence: updates[idx].Sequence},
+			})
+		case strings.HasSuffix(err.Error(), "STALE"):
+			errs[idx] = ErrStaleLocation
+		}
+	}
+	if len(accepted) == 0 {
+		return errs
+	}
+
+	if !indexed {
+		if err := index.Upsert(ctx, moved...); err != nil {
+			for _, idx := range accepted {
+				errs[idx] = err
+			}
+			return errs
+		}
+	}
+
+	pipe := rdb.Pipeline()
+	published := make(map[int]*redis.IntCmd, len(accepted))
+	for _, idx := range accepted {
+		published[idx] = broker.publish(ctx, pipe, updates[idx].Location)
+	}
+
+	pipe.Exec(ctx)
+	for idx, cmd := range published {
+		errs[idx] = cmd.Err()
+	}
+
+	return errs
+}
+
+// ReserveClosestDriver walks the closest drivers in distance order and claims the first FREE one that was seen since the cutoff,
+// the excluded drivers are skipped. When names are given, only these drivers are walked, in their order.
+// The candidates are read without a lock and only the claim is atomic: a driver who got busy or went away in between
+// fails their claim and the next one is tried, when every claim fails the caller searches again.
+func ReserveClosestDriver(ctx context.Context, index geo.GeoIndex, rdb *redis.Client, location *driverv1.LocationMetadata, exclude []string, names []string, cutoff time.Time) (*driverv1.DriverLocation, error) {
+	point := geo.Point{Latitude: location.Latitude, Longitude: location.Longitude}
+	// look past the excluded drivers
+	count := RESERVE_CANDIDATES + len(exclude)
+	if len(names) > 0 {
+		count = 0
+	}
+	candidates, err := index.Nearest(ctx, point, location.Radius, count)
 	if err != nil {
 		return nil, err
 	}
+	if len(names) > 0 {
+		candidates = inOrder(candidates, names)
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = true
+	}
 
-	return locations, nil
+	for _, candidate := range candidates {
+		if excluded[candidate.Name] {
+			continue
+		}
+
+		vehicle, err := claimScript.Run(ctx, rdb, []string{PRESENCE_KEY, candidate.Name, VEHICLES_KEY}, candidate.Name, cutoff.Unix()).Text()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &driverv1.DriverLocation{
+			Name:      candidate.Name,
+			Latitude:  candidate.Latitude,
+			Longitude: candidate.Longitude,
+			Distance:  candidate.Distance,
+			Vehicle:   driverv1.VehicleType(driverv1.VehicleType_value[vehicle]),
+		}, nil
+	}
+
+	return nil, ErrNoDriverAvailable
+}
+
+// inOrder keeps the locations of the names, in the order of the names
+func inOrder(locations []geo.Location, names []string) []geo.Location {
+	byName := make(map[string]geo.Location, len(locations))
+	for _, location := range locations {
+		byName[location.Name] = location
+	}
+
+	ordered := make([]geo.Location, 0, len(names))
+	for _, name := range names {
+		if location, ok := byName[name]; ok {
+			ordered = append(ordered, location)
+			// a name given twice is tried once
+			delete(byName, name)
+		}
+	}
+	return ordered
 }
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestReserveClaimsAFreeDriverOnce(t *testing.T) {
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	index := geo.NewMemoryIndex()
+
+	location := &driverv1.DriverLocation{Name: "driver-0", Latitude: 1 / 111.2, Longitude: 0}
+	if err := UpdateLocation(ctx, rdb, index, NewLocationBroker(zap.NewNop(), rdb), location, time.Now(), 0); err != nil {
+		t.Fatal(err)
+	}
+	rdb.Set(ctx, "driver-0", driverv1.DriverStatus_FREE.String(), 0)
+
+	const RESERVATIONS = 20
+	cutoff := time.Now().Add(-time.Minute)
+	errs := make(chan error, RESERVATIONS)
+
+	var wg sync.WaitGroup
+	for i := 0; i < RESERVATIONS; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ReserveClosestDriver(ctx, index, rdb, &driverv1.LocationMetadata{Radius: 5}, nil, nil, cutoff)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	claimed := 0
+	for err := range errs {
+		switch err {
+		case nil:
+			claimed++
+		case ErrNoDriverAvailable:
+		default:
+			t.Fatal(err)
+		}
+	}
+	if claimed != 1 {
+		t.Fatalf("%d reservations claimed the driver, want 1", claimed)
+	}
+	if status := rdb.Get(ctx, "driver-0").Val(); status != driverv1.DriverStatus_BUSY.String() {
+		t.Fatalf("status = %s, want BUSY", status)
+	}
+}
+
+func TestReserveReturnsTheVehicle(t *testing.T) {
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	index := geo.NewMemoryIndex()
+
+	location := &driverv1.DriverLocation{Name: "driver-0", Latitude: 1 / 111.2, Longitude: 0}
+	if err := UpdateLocation(ctx, rdb, index, NewLocationBroker(zap.NewNop(), rdb), location, time.Now(), 0); err != nil {
+		t.Fatal(err)
+	}
+	rdb.Set(ctx, "driver-0", driverv1.DriverStatus_FREE.String(), 0)
+	rdb.HSet(ctx, VEHICLES_KEY, "driver-0", driverv1.VehicleType_XL.String())
+
+	reserved, err := ReserveClosestDriver(ctx, index, rdb, &driverv1.LocationMetadata{Radius: 5}, nil, nil, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reserved.Name != "driver-0" || reserved.Vehicle != driverv1.VehicleType_XL {
+		t.Fatalf("reserved %+v, want driver-0 and their xl", reserved)
+	}
+}
+
+func TestHeartbeatUsesTheDeviceClock(t *testing.T) {
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	d := &DriverGrpcService{log: zap.NewNop(), rdb: rdb, index: geo.NewMemoryIndex(), broker: NewLocationBroker(zap.NewNop(), rdb)}
+
+	// published by the device a second after it took the heartbeat below
+	taken := time.Now().Add(-time.Minute)
+	location := &driverv1.DriverLocation{Name: "driver-0", Latitude: 1 / 111.2, Longitude: 0}
+	if err := UpdateLocation(ctx, rdb, d.index, d.broker, location, taken.Add(time.Second), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	d.heartbeat(ctx, "driver-0", &driverv1.LocationHeartbeat{Latitude: 2 / 111.2, Timestamp: taken.UnixMilli(), Sequence: 1})
+	d.heartbeat(ctx, "driver-0", &driverv1.LocationHeartbeat{Latitude: 3 / 111.2})
+
+	if at := rdb.HGet(ctx, LOCATION_TIMES_KEY, "driver-0").Val(); at != strconv.FormatInt(taken.Add(time.Second).UnixMilli(), 10) {
+		t.Fatalf("last location at %s, want the published one", at)
+	}
+
+	d.heartbeat(ctx, "driver-0", &driverv1.LocationHeartbeat{Latitude: 2 / 111.2, Timestamp: taken.Add(2 * time.Second).UnixMilli(), Sequence: 2})
+	if at := rdb.HGet(ctx, LOCATION_TIMES_KEY, "driver-0").Val(); at != strconv.FormatInt(taken.Add(2*time.Second).UnixMilli(), 10) {
+		t.Fatalf("last location at %s, want the later heartbeat", at)
+	}
+}
+
+func TestUpdateLocationIndexesTheLatestOfConcurrentWriters(t *testing.T) {
+	tests := []struct {
+		name     string
+		newIndex func(rdb *redis.Client) geo.GeoIndex
+	}{
+		{"memory", func(*redis.Client) geo.GeoIndex { return geo.NewMemoryIndex() }},
+		{"redis", func(rdb *redis.Client) geo.GeoIndex { return geo.NewRedisIndex(rdb, geo.LOCATIONS_KEY) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+			index := tt.newIndex(rdb)
+			broker := NewLocationBroker(zap.NewNop(), rdb)
+
+			// the writers send the locations of the same millisecond, one the even sequence numbers and the other the odd ones
+			const LOCATIONS = 200
+			at := time.Now()
+			var wg sync.WaitGroup
+			for writer := 0; writer < 2; writer++ {
+				wg.Add(1)
+				go func(first uint64) {
+					defer wg.Done()
+					for sequence := first; sequence < LOCATIONS; sequence += 2 {
+						location := &driverv1.DriverLocation{Name: "driver-0", Latitude: float64(sequence) / 1000, Longitude: 0}
+						if err := UpdateLocation(ctx, rdb, index, broker, location, at, sequence); err != nil && err != ErrStaleLocation {
+							t.Error(err)
+						}
+					}
+				}(uint64(writer))
+			}
+			wg.Wait()
+
+			latest := geo.Point{Latitude: float64(LOCATIONS-1) / 1000}
+			locations, err := index.Nearest(ctx, latest, 0.05, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(locations) != 1 {
+				all, _ := index.Nearest(ctx, latest, 50, 0)
+				t.Fatalf("the driver is indexed at %v, want the location of sequence %d", all, LOCATIONS-1)
+			}
+		})
+	}
+}
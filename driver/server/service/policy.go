@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+)
+
+// policy lists the roles allowed to call every rpc, the handlers check that drivers only act as themselves
+var policy = interceptor.Policy{
+	"/driver.v1.Driver/GetClosest": {token.RoleService, token.RoleAdmin},
+	"/driver.v1.Driver/GetStatus":  {token.RoleDriver, token.RoleService, token.RoleAdmin},
+	"/driver.v1.Driver/SetStatus":  {token.RoleDriver, token.RoleService, token.RoleAdmin},
+	"/driver.v1.Driver/Reserve":    {token.RoleService, token.RoleAdmin},
+	"/driver.v1.Driver/GoOnline":   {token.RoleDriver, token.RoleAdmin},
+	"/driver.v1.Driver/GoOffline":  {token.RoleDriver, token.RoleAdmin},
+	"/driver.v1.Driver/ListOnline": {token.RoleService, token.RoleAdmin},
+	"/driver.v1.Driver/Watch":      {token.RoleService, token.RoleAdmin},
+	"/driver.v1.Driver/Session":    {token.RoleDriver},
+	"/driver.v1.Driver/Offer":      {token.RoleService},
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// PRESENCE_KEY is a sorted set of driver names scored by the unix time they were last seen at
+const PRESENCE_KEY = "drivers/presence"
+
+// reapScript removes the drivers not seen since the cutoff from the presence set and returns them
+var reapScript = redis.NewScript(`
+local stale = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', '(' .. ARGV[1])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', '(' .. ARGV[1])
+return stale
+`)
+
+// Presence tracks when every driver was last seen and drops the ones gone quiet for longer than the window
+type Presence struct {
+	log      *zap.Logger
+	rdb      *redis.Client
+	index    geo.GeoIndex
+	window   time.Duration
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewPresence(
+	lc fx.Lifecycle,
+	log *zap.Logger,
+	rdb *redis.Client,
+	index geo.GeoIndex,
+	cfg *config.Config,
+) *Presence {
+	p := &Presence{
+		log:      log,
+		rdb:      rdb,
+		index:    index,
+		window:   cfg.Driver.PresenceWindow,
+		interval: cfg.Driver.ReaperInterval,
+		now:      time.Now,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			log.Info("Starting presence reaper...", zap.Duration("window", p.window))
+			go p.run(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+
+	return p
+}
+
+// Cutoff is the time before which drivers are considered gone
+func (p *Presence) Cutoff() time.Time {
+	return p.now().Add(-p.window)
+}
+
+func (p *Presence) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reaped, err := p.Reap(ctx)
+			if err != nil {
+				p.log.Error("Cannot reap the stale drivers", zap.Error(err))
+				continue
+			}
+			if reaped > 0 {
+				p.log.Info("Reaped stale drivers", zap.Int64("count", reaped))
+			}
+		}
+	}
+}
+
+// Reap removes the stale drivers from the presence set, then from the geo index.
+// A driver seen again in between is indexed again by their next location.
+func (p *Presence) Reap(ctx context.Context) (int64, error) {
+	stale, err := reapScript.Run(
+		ctx,
+		p.rdb,
+		[]string{PRESENCE_KEY},
+		p.Cutoff().Unix(),
+	).StringSlice()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(stale)), p.index.Remove(ctx, stale...)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestReapRemovesStaleDrivers(t *testing.T) {
+	index, rdb := newSearchEnv(t)
+	ctx := context.Background()
+	presence := &Presence{log: zap.NewNop(), rdb: rdb, index: index, window: time.Minute, now: time.Now}
+
+	reaped, err := presence.Reap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaped != 0 {
+		t.Fatalf("Reap() = %d, want no driver reaped within the window", reaped)
+	}
+
+	// the clock moves past the window, only driver-0 was seen since
+	later := time.Now().Add(time.Minute + 5*time.Second)
+	if err := rdb.ZAdd(ctx, PRESENCE_KEY, redis.Z{Score: float64(later.Unix()), Member: "driver-0"}).Err(); err != nil {
+		t.Fatal(err)
+	}
+	presence.now = func() time.Time { return later }
+
+	reaped, err = presence.Reap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaped != 9 {
+		t.Fatalf("Reap() = %d, want the 9 quiet drivers reaped", reaped)
+	}
+
+	if seen := rdb.ZRange(ctx, PRESENCE_KEY, 0, -1).Val(); len(seen) != 1 || seen[0] != "driver-0" {
+		t.Fatalf("presence = %v, want only driver-0", seen)
+	}
+	indexed, err := index.Nearest(ctx, geo.Point{}, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexed) != 1 || indexed[0].Name != "driver-0" {
+		t.Fatalf("geo index = %v, want only driver-0", indexed)
+	}
+
+	locations, _, err := GetClosestDriver(ctx, index, rdb, &driverv1.GetClosestRequest{Radius: 20}, presence.Cutoff())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(locations); len(got) != 1 || got[0] != "driver-0" {
+		t.Fatalf("GetClosestDriver() = %v, want only driver-0", got)
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrInvalidPageToken = status.Error(codes.InvalidArgument, "invalid page token")
+
+// VEHICLES_KEY is a hash of the vehicle type of every driver, set when their shift starts
+const VEHICLES_KEY = "drivers/vehicles"
+
+const (
+	DEFAULT_MAX_RESULTS = 10
+	MAX_RESULTS         = 100
+	KM_PER_MILE         = 1.609344
+)
+
+// kmPerUnit is the length of the unit in km
+func kmPerUnit(unit driverv1.DistanceUnit) float64 {
+	switch unit {
+	case driverv1.DistanceUnit_METERS:
+		return 0.001
+	case driverv1.DistanceUnit_MILES:
+		return KM_PER_MILE
+	default:
+		return 1
+	}
+}
+
+// cursor is the last driver of a page, the next page starts after it.
+// Drivers are ordered by distance, then by name, so the ones at the same distance are not skipped.
+type cursor struct {
+	distance float64
+	name     string
+}
+
+func (c cursor) before(location geo.Location) bool {
+	return c.distance < location.Distance || (c.distance == location.Distance && c.name < location.Name)
+}
+
+// the token is opaque to the callers, the distance is in km whatever the unit of the request
+func (c cursor) token() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatFloat(c.distance, 'g', -1, 64) + "/" + c.name))
+}
+
+func parsePageToken(token string) (*cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	distance, name, found := strings.Cut(string(raw), "/")
+	if !found {
+		return nil, ErrInvalidPageToken
+	}
+	parsed, err := strconv.ParseFloat(distance, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &cursor{distance: parsed, name: name}, nil
+}
+
+// GetClosestDriver returns a page of the drivers around the location that were seen since the cutoff and match the filters
+// of the request, with the token of the next page
+func GetClosestDriver(ctx context.Context, index geo.GeoIndex, rdb *redis.Client, req *driverv1.GetClosestRequest, cutoff time.Time) ([]*driverv1.DriverLocation, string, error) {
+	if req.MaxResults < 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "negative max results")
+	}
+	maxResults := int(req.MaxResults)
+	if maxResults == 0 {
+		maxResults = DEFAULT_MAX_RESULTS
+	}
+	if maxResults > MAX_RESULTS {
+		maxResults = MAX_RESULTS
+	}
+
+	after, err := parsePageToken(req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	unit := kmPerUnit(req.Unit)
+	point := geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}
+	// the radius bounds the search, every page walks the drivers in it again
+	candidates, err := index.Nearest(ctx, point, req.Radius*unit, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return cursor{candidates[i].Distance, candidates[i].Name}.before(candidates[j])
+	})
+	if after != nil {
+		start := sort.Search(len(candidates), func(i int) bool { return after.before(candidates[i]) })
+		candidates = candidates[start:]
+	}
+
+	filter := newDriverFilter(req, cutoff)
+	page := make([]*driverv1.DriverLocation, 0, maxResults)
+	for len(page) < maxResults && len(candidates) > 0 {
+		chunk := candidates
+		if len(chunk) > maxResults {
+			chunk = chunk[:maxResults]
+		}
+		candidates = candidates[len(chunk):]
+
+		matches, err := filter.apply(ctx, rdb, chunk)
+		if err != nil {
+			return nil, "", err
+		}
+		for idx, candidate := range chunk {
+			if !matches[idx].ok {
+				continue
+			}
+			page = append(page, &driverv1.DriverLocation{
+				Name:      candidate.Name,
+				Latitude:  candidate.Latitude,
+				Longitude: candidate.Longitude,
+				Distance:  candidate.Distance / unit,
+				Vehicle:   matches[idx].vehicle,
+				Heading:   matches[idx].heading,
+				FreeSince: matches[idx].freeSince,
+			})
+			if len(page) == maxResults {
+				// the next page starts after the last driver of this one, not after the whole chunk
+				last := cursor{candidate.Distance, candidate.Name}
+				if idx < len(chunk)-1 || len(candidates) > 0 {
+					return page, last.token(), nil
+				}
+				return page, "", nil
+			}
+		}
+	}
+
+	return page, "", nil
+}
+
+// driverFilter holds the filters of a GetClosest request
+type driverFilter struct {
+	cutoff   time.Time
+	status   driverv1.DriverStatus
+	vehicles map[driverv1.VehicleType]bool
+	excluded map[string]bool
+}
+
+type filterResult struct {
+	ok        bool
+	vehicle   driverv1.VehicleType
+	heading   *float64
+	freeSince int64
+}
+
+func newDriverFilter(req *driverv1.GetClosestRequest, cutoff time.Time) *driverFilter {
+	filter := &driverFilter{
+		cutoff:   cutoff,
+		status:   req.Status,
+		vehicles: make(map[driverv1.VehicleType]bool, len(req.VehicleTypes)),
+		excluded: make(map[string]bool, len(req.Exclude)),
+	}
+	for _, vehicle := range req.VehicleTypes {
+		filter.vehicles[vehicle] = true
+	}
+	for _, name := range req.Exclude {
+		filter.excluded[name] = true
+	}
+
+	return filter
+}
+
+// apply reads the presence, the status, the vehicle, the heading and the free time of the drivers in a single pipeline
+// and checks them against the filters
+func (f *driverFilter) apply(ctx context.Context, rdb *redis.Client, locations []geo.Location) ([]filterResult, error) {
+	pipe := rdb.Pipeline()
+	seen := make([]*redis.FloatCmd, len(locations))
+	statuses := make([]*redis.StringCmd, len(locations))
+	vehicles := make([]*redis.StringCmd, len(locations))
+	headings := make([]*redis.StringCmd, len(locations))
+	freeSince := make([]*redis.StringCmd, len(locations))
+	for idx, location := range locations {
+		// the reaper may not have run yet, the stale drivers are filtered out too
+		seen[idx] = pipe.ZScore(ctx, PRESENCE_KEY, location.Name)
+		statuses[idx] = pipe.Get(ctx, location.Name)
+		vehicles[idx] = pipe.HGet(ctx, VEHICLES_KEY, location.Name)
+		headings[idx] = pipe.HGet(ctx, LOCATION_HEADINGS_KEY, location.Name)
+		freeSince[idx] = pipe.HGet(ctx, FREE_SINCE_KEY, location.Name)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	results := make([]filterResult, len(locations))
+	for idx, location := range locations {
+		// the drivers that never set their vehicle drive a STANDARD one
+		vehicle := driverv1.VehicleType(driverv1.VehicleType_value[vehicles[idx].Val()])
+		results[idx] = filterResult{
+			ok: !f.excluded[location.Name] &&
+				seen[idx].Err() == nil && seen[idx].Val() >= float64(f.cutoff.Unix()) &&
+				(f.status == driverv1.DriverStatus_UNKNOWN || driverv1.DriverStatus_value[statuses[idx].Val()] == int32(f.status)) &&
+				(len(f.vehicles) == 0 || f.vehicles[vehicle]),
+			vehicle: vehicle,
+		}
+		if heading, err := headings[idx].Float64(); err == nil {
+			results[idx].heading = &heading
+		}
+		results[idx].freeSince, _ = freeSince[idx].Int64()
+	}
+
+	return results, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// newSearchEnv places driver-0 to driver-9 north of the origin, 1km apart and FREE,
+// driver-3 is BUSY and driver-4 and driver-5 drive an XL
+func newSearchEnv(t *testing.T) (geo.GeoIndex, *redis.Client) {
+	t.Helper()
+
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	index := geo.NewMemoryIndex()
+	broker := NewLocationBroker(zap.NewNop(), rdb)
+
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("driver-%d", i)
+		location := &driverv1.DriverLocation{Name: name, Latitude: float64(i+1) / 111.2, Longitude: 0}
+		if err := UpdateLocation(ctx, rdb, index, broker, location, time.Now(), 0); err != nil {
+			t.Fatal(err)
+		}
+		rdb.Set(ctx, name, driverv1.DriverStatus_FREE.String(), 0)
+	}
+	rdb.Set(ctx, "driver-3", driverv1.DriverStatus_BUSY.String(), 0)
+	rdb.HSet(ctx, VEHICLES_KEY, "driver-4", driverv1.VehicleType_XL.String(), "driver-5", driverv1.VehicleType_XL.String())
+
+	return index, rdb
+}
+
+func names(locations []*driverv1.DriverLocation) []string {
+	names := make([]string, len(locations))
+	for idx, location := range locations {
+		names[idx] = location.Name
+	}
+	return names
+}
+
+func TestGetClosestDriverFilters(t *testing.T) {
+	index, rdb := newSearchEnv(t)
+	cutoff := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		req  *driverv1.GetClosestRequest
+		want []string
+	}{
+		{"radius", &driverv1.GetClosestRequest{Radius: 3.5}, []string{"driver-0", "driver-1", "driver-2"}},
+		{"meters", &driverv1.GetClosestRequest{Radius: 2500, Unit: driverv1.DistanceUnit_METERS}, []string{"driver-0", "driver-1"}},
+		{"miles", &driverv1.GetClosestRequest{Radius: 2, Unit: driverv1.DistanceUnit_MILES}, []string{"driver-0", "driver-1", "driver-2"}},
+		{"max results", &driverv1.GetClosestRequest{Radius: 20, MaxResults: 2}, []string{"driver-0", "driver-1"}},
+		{"status", &driverv1.GetClosestRequest{Radius: 5.5, Status: driverv1.DriverStatus_FREE}, []string{"driver-0", "driver-1", "driver-2", "driver-4"}},
+		{"vehicle", &driverv1.GetClosestRequest{Radius: 20, VehicleTypes: []driverv1.VehicleType{driverv1.VehicleType_XL}}, []string{"driver-4", "driver-5"}},
+		{"exclude", &driverv1.GetClosestRequest{Radius: 3.5, Exclude: []string{"driver-1"}}, []string{"driver-0", "driver-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations, _, err := GetClosestDriver(context.Background(), index, rdb, tt.req, cutoff)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(locations); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetClosestDriver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("stale", func(t *testing.T) {
+		locations, _, err := GetClosestDriver(context.Background(), index, rdb, &driverv1.GetClosestRequest{Radius: 20}, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(locations) != 0 {
+			t.Fatalf("GetClosestDriver() = %v, want no stale driver", names(locations))
+		}
+	})
+}
+
+func TestGetClosestDriverDistanceUnit(t *testing.T) {
+	index, rdb := newSearchEnv(t)
+
+	locations, _, err := GetClosestDriver(context.Background(), index, rdb, &driverv1.GetClosestRequest{Radius: 1500, Unit: driverv1.DistanceUnit_METERS}, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 1 || locations[0].Distance < 999 || locations[0].Distance > 1001 {
+		t.Fatalf("GetClosestDriver() = %v, want driver-0 1000m away", locations)
+	}
+}
+
+func TestGetClosestDriverPages(t *testing.T) {
+	index, rdb := newSearchEnv(t)
+	cutoff := time.Now().Add(-time.Minute)
+	req := &driverv1.GetClosestRequest{Radius: 20, MaxResults: 3, Status: driverv1.DriverStatus_FREE}
+
+	var got []string
+	for pages := 0; ; pages++ {
+		if pages == 5 {
+			t.Fatalf("GetClosestDriver() did not reach the last page, got %v", got)
+		}
+		locations, next, err := GetClosestDriver(context.Background(), index, rdb, req, cutoff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, names(locations)...)
+		if next == "" {
+			break
+		}
+		req.PageToken = next
+	}
+
+	want := []string{"driver-0", "driver-1", "driver-2", "driver-4", "driver-5", "driver-6", "driver-7", "driver-8", "driver-9"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetClosestDriver() pages = %v, want %v", got, want)
+	}
+
+	req.PageToken = "not a token"
+	if _, _, err := GetClosestDriver(context.Background(), index, rdb, req, cutoff); err != ErrInvalidPageToken {
+		t.Fatalf("GetClosestDriver() = %v, want %v", err, ErrInvalidPageToken)
+	}
+}
+
+func TestGetClosestDriverHeadingAndFreeSince(t *testing.T) {
+	index, rdb := newSearchEnv(t)
+	ctx := context.Background()
+
+	heading := 90.0
+	location := &driverv1.DriverLocation{Name: "driver-0", Latitude: 1 / 111.2, Longitude: 0, Heading: &heading}
+	if err := UpdateLocation(ctx, rdb, index, NewLocationBroker(zap.NewNop(), rdb), location, time.Now(), 1); err != nil {
+		t.Fatal(err)
+	}
+	rdb.HSet(ctx, FREE_SINCE_KEY, "driver-0", 1234)
+
+	locations, _, err := GetClosestDriver(ctx, index, rdb, &driverv1.GetClosestRequest{Radius: 2.5}, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locations[0].Heading == nil || *locations[0].Heading != heading || locations[0].FreeSince != 1234 {
+		t.Fatalf("GetClosestDriver() = %v, want driver-0 heading %v and free since 1234", locations[0], heading)
+	}
+	if locations[1].Heading != nil {
+		t.Fatalf("GetClosestDriver() = %v, want driver-1 without a heading", locations[1])
+	}
+}
+
+func TestReserveClosestDriverInOrder(t *testing.T) {
+	index, rdb := newSearchEnv(t)
+	location := &driverv1.LocationMetadata{Radius: 20}
+	cutoff := time.Now().Add(-time.Minute)
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		driver, err := ReserveClosestDriver(context.Background(), index, rdb, location, nil, []string{"driver-3", "driver-7", "unknown", "driver-2"}, cutoff)
+		if err != nil {
+			got = append(got, err.Error())
+			continue
+		}
+		got = append(got, driver.Name)
+	}
+
+	want := []string{"driver-7", "driver-2", ErrNoDriverAvailable.Error()}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReserveClosestDriver() = %v, want %v", got, want)
+	}
+}
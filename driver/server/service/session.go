@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrNoSession = status.Error(codes.Unavailable, "the driver has no open session")
+
+// session is the open Session stream of a driver's device
+type session struct {
+	name   string
+	outbox chan *driverv1.DriverSessionResponse
+	closed chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]chan *driverv1.OfferReply
+}
+
+func newSession(name string) *session {
+	return &session{
+		name:    name,
+		outbox:  make(chan *driverv1.DriverSessionResponse),
+		closed:  make(chan struct{}),
+		pending: make(map[string]chan *driverv1.OfferReply),
+	}
+}
+
+// offer sends the ride to the driver and waits for their reply
+func (s *session) offer(ctx context.Context, offer *driverv1.RideOffer) (*driverv1.OfferReply, error) {
+	reply := make(chan *driverv1.OfferReply, 1)
+
+	s.mu.Lock()
+	s.pending[offer.OfferId] = reply
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, offer.OfferId)
+		s.mu.Unlock()
+	}()
+
+	select {
+	case s.outbox <- &driverv1.DriverSessionResponse{Message: &driverv1.DriverSessionResponse_Offer{Offer: offer}}:
+	case <-s.closed:
+		return nil, ErrNoSession
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	select {
+	case r := <-reply:
+		return r, nil
+	case <-s.closed:
+		return nil, ErrNoSession
+	case <-ctx.Done():
+		// let the driver's device know the offer is gone, without waiting on it
+		go func() {
+			select {
+			case s.outbox <- &driverv1.DriverSessionResponse{Message: &driverv1.DriverSessionResponse_Withdrawn{
+				Withdrawn: &driverv1.OfferWithdrawn{OfferId: offer.OfferId},
+			}}:
+			case <-s.closed:
+			case <-time.After(SESSION_SEND_TIMEOUT):
+			}
+		}()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// resolve hands the driver's reply to the pending offer, late replies are dropped
+func (s *session) resolve(reply *driverv1.OfferReply) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.pending[reply.OfferId]
+	if ok {
+		pending <- reply
+		delete(s.pending, reply.OfferId)
+	}
+	return ok
+}
+
+// sessionRegistry keeps the open session of every connected driver
+type sessionRegistry struct {
+	mu       sync.RWMutex
+	sessions map[string]*session
+}
+
+func newSessionRegistry() *sessionRegistry {
+	return &sessionRegistry{
+		sessions: make(map[string]*session),
+	}
+}
+
+// register replaces any older session of the same driver
+func (r *sessionRegistry) register(s *session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[s.name] = s
+}
+
+func (r *sessionRegistry) unregister(s *session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sessions[s.name] == s {
+		delete(r.sessions, s.name)
+	}
+	close(s.closed)
+}
+
+func (r *sessionRegistry) get(name string) (*session, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.sessions[name]
+	return s, ok
+}
+
+const SESSION_SEND_TIMEOUT = 5 * time.Second
+
+func (d *DriverGrpcService) Session(stream driverv1.Driver_SessionServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil || start.Name == "" {
+		return status.Error(codes.InvalidArgument, "the session must start with the driver's name")
+	}
+	if !interceptor.Owns(ctx, start.Name) {
+		return interceptor.ErrNotOwner
+	}
+
+	s := newSession(start.Name)
+	d.sessions.register(s)
+	defer d.sessions.unregister(s)
+
+	d.log.Info("Session opened", zap.String("method", "Session"), zap.String("drivername", s.name))
+	defer d.log.Info("Session closed", zap.String("drivername", s.name))
+
+	// the only goroutine sending on the stream
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-s.outbox:
+				if err := stream.Send(msg); err != nil {
+					d.log.Error("Cannot send to the session", zap.String("drivername", s.name), zap.Error(err))
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg := req.Message.(type) {
+		case *driverv1.DriverSessionRequest_Heartbeat:
+			d.heartbeat(ctx, s.name, msg.Heartbeat)
+		case *driverv1.DriverSessionRequest_Reply:
+			if !s.resolve(msg.Reply) {
+				d.log.Warn("Reply to an unknown offer", zap.String("drivername", s.name), zap.String("offerid", msg.Reply.OfferId))
+			}
+		default:
+			d.log.Warn("Unexpected session message", zap.String("drivername", s.name))
+		}
+	}
+}
+
+// heartbeat updates the driver's location with the time their device took it at,
+// so the heartbeats and the published locations are ordered on the same clock
+func (d *DriverGrpcService) heartbeat(ctx context.Context, name string, heartbeat *driverv1.LocationHeartbeat) {
+	var at time.Time
+	if heartbeat.Timestamp > 0 {
+		at = time.UnixMilli(heartbeat.Timestamp)
+	}
+	log := d.log.With(zap.String("drivername", name), zap.Time("at", at), zap.Uint64("sequence", heartbeat.Sequence))
+
+	// the same checks as the published locations, the index takes whatever it is given
+	if err := ValidateLocation(heartbeat.Latitude, heartbeat.Longitude, heartbeat.Heading, at, time.Now()); err != nil {
+		log.Warn("Dropped an invalid heartbeat", zap.Error(err))
+		return
+	}
+
+	err := UpdateLocation(ctx, d.rdb, d.index, d.broker, &driverv1.DriverLocation{
+		Name:      name,
+		Latitude:  heartbeat.Latitude,
+		Longitude: heartbeat.Longitude,
+		Heading:   heartbeat.Heading,
+	}, at, heartbeat.Sequence)
+	switch {
+	case err == ErrStaleLocation:
+		// a later location was published meanwhile, or the heartbeats came out of order
+		log.Info("Dropped a stale heartbeat")
+	case err != nil:
+		log.Error("Cannot update the driver's location", zap.Error(err))
+	}
+}
+
+func (d *DriverGrpcService) Offer(ctx context.Context, offer *driverv1.RideOffer) (*driverv1.OfferReply, error) {
+	s, ok := d.sessions.get(offer.Driver)
+	if !ok {
+		return nil, ErrNoSession
+	}
+
+	if offer.OfferId == "" {
+		offer.OfferId = uuid.New().String()
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		offer.ExpiresAt = deadline.UnixMilli()
+	}
+
+	d.log.Info("Offering ride",
+		zap.String("method", "Offer"),
+		zap.String("offerid", offer.OfferId),
+		zap.String("rideid", offer.RideId),
+		zap.String("drivername", offer.Driver),
+	)
+
+	reply, err := s.offer(ctx, offer)
+	if err != nil {
+		d.log.Info("Offer got no reply", zap.String("offerid", offer.OfferId), zap.Error(err))
+		return nil, err
+	}
+
+	d.log.Info("Offer answered", zap.String("offerid", offer.OfferId), zap.String("decision", reply.Decision.String()))
+
+	return reply, nil
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type sessionEnv struct {
+	service *DriverGrpcService
+	lis     *bufconn.Listener
+	tokens  *token.Manager
+	// the ride service's client
+	rides driverv1.DriverClient
+}
+
+func newSessionEnv(t *testing.T) *sessionEnv {
+	t.Helper()
+
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	d := &DriverGrpcService{
+		log:      zap.NewNop(),
+		rdb:      rdb,
+		index:    geo.NewMemoryIndex(),
+		broker:   NewLocationBroker(zap.NewNop(), rdb),
+		sessions: newSessionRegistry(),
+	}
+	tokens := token.NewManager([]byte("test-secret"), time.Minute)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary(tokens, policy)),
+		grpc.StreamInterceptor(interceptor.Stream(tokens, policy)),
+	)
+	driverv1.RegisterDriverServer(server, d)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	env := &sessionEnv{service: d, lis: lis, tokens: tokens}
+	env.rides = env.dial(t, "ride-server", token.RoleService)
+	return env
+}
+
+// dial connects to the driver service as the given identity
+func (env *sessionEnv) dial(t *testing.T, subject string, role token.Role) driverv1.DriverClient {
+	t.Helper()
+
+	signed, _, err := env.tokens.Issue(subject, role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return env.lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(interceptor.Bearer(signed)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return driverv1.NewDriverClient(conn)
+}
+
+// open starts a session of the driver and waits until it is the one the offers go to
+func (env *sessionEnv) open(t *testing.T, ctx context.Context, name string) driverv1.Driver_SessionClient {
+	t.Helper()
+
+	before, _ := env.service.sessions.get(name)
+
+	stream, err := env.dial(t, name, token.RoleDriver).Session(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&driverv1.DriverSessionRequest{Message: &driverv1.DriverSessionRequest_Start{
+		Start: &driverv1.SessionStart{Name: name},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if s, ok := env.service.sessions.get(name); ok && s != before {
+			return stream
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the session of %s did not open", name)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+type offerResult struct {
+	reply *driverv1.OfferReply
+	err   error
+}
+
+// offer sends the ride to the driver as the ride service, the result comes on the channel
+func (env *sessionEnv) offer(ctx context.Context, offerID string) <-chan offerResult {
+	result := make(chan offerResult, 1)
+	go func() {
+		reply, err := env.rides.Offer(ctx, &driverv1.RideOffer{OfferId: offerID, Driver: "driver-0", RideId: "ride-" + offerID})
+		result <- offerResult{reply: reply, err: err}
+	}()
+	return result
+}
+
+func recvOffer(t *testing.T, stream driverv1.Driver_SessionClient) *driverv1.RideOffer {
+	t.Helper()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	offer := resp.GetOffer()
+	if offer == nil {
+		t.Fatalf("received %v, want an offer", resp)
+	}
+	return offer
+}
+
+func reply(t *testing.T, stream driverv1.Driver_SessionClient, offerID string, decision driverv1.OfferDecision) {
+	t.Helper()
+
+	if err := stream.Send(&driverv1.DriverSessionRequest{Message: &driverv1.DriverSessionRequest_Reply{
+		Reply: &driverv1.OfferReply{OfferId: offerID, Decision: decision},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOfferIsAnswered(t *testing.T) {
+	for _, decision := range []driverv1.OfferDecision{driverv1.OfferDecision_ACCEPT, driverv1.OfferDecision_DECLINE} {
+		t.Run(decision.String(), func(t *testing.T) {
+			env := newSessionEnv(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			stream := env.open(t, ctx, "driver-0")
+			result := env.offer(ctx, "offer-1")
+
+			offer := recvOffer(t, stream)
+			if offer.OfferId != "offer-1" || offer.RideId != "ride-offer-1" || offer.ExpiresAt == 0 {
+				t.Fatalf("offer = %+v, want offer-1 of ride-offer-1 with its deadline", offer)
+			}
+			reply(t, stream, offer.OfferId, decision)
+
+			got := <-result
+			if got.err != nil {
+				t.Fatal(got.err)
+			}
+			if got.reply.OfferId != "offer-1" || got.reply.Decision != decision {
+				t.Fatalf("reply = %+v, want %s of offer-1", got.reply, decision)
+			}
+		})
+	}
+}
+
+func TestOfferWithoutSession(t *testing.T) {
+	env := newSessionEnv(t)
+
+	got := <-env.offer(context.Background(), "offer-1")
+	if status.Code(got.err) != codes.Unavailable {
+		t.Fatalf("Offer() = %v, want Unavailable without a session", got.err)
+	}
+}
+
+func TestOfferTimesOutAndIsWithdrawn(t *testing.T) {
+	env := newSessionEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream := env.open(t, ctx, "driver-0")
+
+	offerCtx, cancelOffer := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancelOffer()
+	result := env.offer(offerCtx, "offer-1")
+	recvOffer(t, stream)
+
+	if got := <-result; status.Code(got.err) != codes.DeadlineExceeded {
+		t.Fatalf("Offer() = %v, want DeadlineExceeded without a reply", got.err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdrawn := resp.GetWithdrawn(); withdrawn == nil || withdrawn.OfferId != "offer-1" {
+		t.Fatalf("received %v, want offer-1 withdrawn", resp)
+	}
+
+	// the late reply is dropped, it doesn't answer the next offer
+	reply(t, stream, "offer-1", driverv1.OfferDecision_ACCEPT)
+	result = env.offer(ctx, "offer-2")
+	offer := recvOffer(t, stream)
+	reply(t, stream, offer.OfferId, driverv1.OfferDecision_DECLINE)
+
+	got := <-result
+	if got.err != nil {
+		t.Fatal(got.err)
+	}
+	if got.reply.OfferId != "offer-2" || got.reply.Decision != driverv1.OfferDecision_DECLINE {
+		t.Fatalf("reply = %+v, want offer-2 declined", got.reply)
+	}
+}
+
+func TestSessionReplacesTheOlderOne(t *testing.T) {
+	env := newSessionEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oldCtx, closeOld := context.WithCancel(ctx)
+	env.open(t, oldCtx, "driver-0")
+	stream := env.open(t, ctx, "driver-0")
+
+	// the older session closing doesn't take the new one with it
+	current, _ := env.service.sessions.get("driver-0")
+	closeOld()
+	time.Sleep(50 * time.Millisecond)
+	if s, ok := env.service.sessions.get("driver-0"); !ok || s != current {
+		t.Fatal("the older session closing unregistered the new one")
+	}
+
+	result := env.offer(ctx, "offer-1")
+	offer := recvOffer(t, stream)
+	reply(t, stream, offer.OfferId, driverv1.OfferDecision_ACCEPT)
+	if got := <-result; got.err != nil || got.reply.Decision != driverv1.OfferDecision_ACCEPT {
+		t.Fatalf("Offer() = %+v, %v, want the new session to accept", got.reply, got.err)
+	}
+}
+
+func TestHeartbeatDropsInvalidLocations(t *testing.T) {
+	env := newSessionEnv(t)
+	ctx := context.Background()
+
+	now := time.Now().UnixMilli()
+	heading := 400.0
+	for _, heartbeat := range []*driverv1.LocationHeartbeat{
+		{Latitude: 91, Longitude: 27.59, Timestamp: now, Sequence: 1},
+		{Latitude: math.NaN(), Longitude: 27.59, Timestamp: now, Sequence: 2},
+		{Latitude: 47.16, Longitude: 181, Timestamp: now, Sequence: 3},
+		{Latitude: 47.16, Longitude: 27.59, Heading: &heading, Timestamp: now, Sequence: 4},
+		{Latitude: 47.16, Longitude: 27.59, Sequence: 5},
+		{Latitude: 47.16, Longitude: 27.59, Timestamp: time.Now().Add(time.Hour).UnixMilli(), Sequence: 6},
+	} {
+		env.service.heartbeat(ctx, "driver-0", heartbeat)
+	}
+
+	if times := env.service.rdb.HLen(ctx, LOCATION_TIMES_KEY).Val(); times != 0 {
+		t.Fatalf("%d locations cached, want none of the invalid heartbeats", times)
+	}
+	locations, err := env.service.index.Nearest(ctx, geo.Point{Latitude: 47.16, Longitude: 27.59}, 20000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 0 {
+		t.Fatalf("indexed %+v, want none of the invalid heartbeats", locations)
+	}
+}
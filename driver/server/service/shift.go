@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ONLINE_KEY is a hash of the drivers currently on shift, with the time their shift started
+const ONLINE_KEY = "drivers/online"
+
+func shiftsKey(name string) string {
+	return fmt.Sprintf("drivers/shifts/%s", name)
+}
+
+// goOnlineScript starts the driver's shift unless it's already started, keeps their vehicle and makes them FREE,
+// a driver on a ride stays BUSY
+var goOnlineScript = redis.NewScript(`
+local started = redis.call('HGET', KEYS[1], ARGV[1])
+if not started then
+	started = ARGV[2]
+	redis.call('HSET', KEYS[1], ARGV[1], started)
+end
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[3])
+local status = redis.call('GET', KEYS[4])
+if status ~= 'BUSY' then
+	if status ~= 'FREE' then
+		redis.call('HSET', KEYS[3], ARGV[1], ARGV[2])
+	end
+	redis.call('SET', KEYS[4], 'FREE')
+end
+return started
+`)
+
+// goOfflineScript ends the driver's shift, appends it to their shift history and takes them out of matching,
+// the caller removes them from the geo index.
+// It returns the shift start, or an error reply when the driver is not online or is on a ride.
+var goOfflineScript = redis.NewScript(`
+local started = redis.call('HGET', KEYS[1], ARGV[1])
+if not started then
+	return redis.error_reply('NOT_ONLINE')
+end
+if redis.call('GET', KEYS[4]) == 'BUSY' then
+	return redis.error_reply('BUSY')
+end
+redis.call('SET', KEYS[4], 'OFFLINE')
+redis.call('HDEL', KEYS[1], ARGV[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('RPUSH', KEYS[3], cjson.encode({startedAt = tonumber(started), endedAt = tonumber(ARGV[2])}))
+return started
+`)
+
+func (d *DriverGrpcService) GoOnline(ctx context.Context, req *driverv1.ShiftRequest) (*driverv1.Shift, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing driver name")
+	}
+	if !interceptor.Owns(ctx, req.Name) {
+		return nil, interceptor.ErrNotOwner
+	}
+
+	startedAt, err := goOnlineScript.Run(
+		ctx,
+		d.rdb,
+		[]string{ONLINE_KEY, VEHICLES_KEY, FREE_SINCE_KEY, req.Name},
+		req.Name,
+		time.Now().UnixMilli(),
+		req.Vehicle.String(),
+	).Int64()
+	if err != nil {
+		return nil, err
+	}
+
+	d.log.Info("GoOnline", zap.String("drivername", req.Name), zap.String("vehicle", req.Vehicle.String()), zap.Int64("startedAt", startedAt))
+
+	return &driverv1.Shift{
+		Name:      req.Name,
+		StartedAt: startedAt,
+	}, nil
+}
+
+func (d *DriverGrpcService) GoOffline(ctx context.Context, req *driverv1.ShiftRequest) (*driverv1.Shift, error) {
+	if !interceptor.Owns(ctx, req.Name) {
+		return nil, interceptor.ErrNotOwner
+	}
+
+	endedAt := time.Now().UnixMilli()
+
+	startedAt, err := goOfflineScript.Run(
+		ctx,
+		d.rdb,
+		[]string{ONLINE_KEY, PRESENCE_KEY, shiftsKey(req.Name), req.Name},
+		req.Name,
+		endedAt,
+	).Int64()
+	switch {
+	case err == nil:
+	case strings.HasSuffix(err.Error(), "NOT_ONLINE"):
+		return nil, status.Error(codes.FailedPrecondition, "the driver is not online")
+	case strings.HasSuffix(err.Error(), "BUSY"):
+		return nil, status.Error(codes.FailedPrecondition, "the driver is on a ride")
+	default:
+		return nil, err
+	}
+
+	// not being seen, the driver can't be reserved anymore even if this fails
+	if err := d.index.Remove(ctx, req.Name); err != nil {
+		d.log.Error("Cannot remove the driver from the geo index", zap.String("drivername", req.Name), zap.Error(err))
+	}
+
+	d.log.Info("GoOffline", zap.String("drivername", req.Name), zap.Int64("startedAt", startedAt), zap.Int64("endedAt", endedAt))
+
+	return &driverv1.Shift{
+		Name:      req.Name,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+	}, nil
+}
+
+func (d *DriverGrpcService) ListOnline(ctx context.Context, _ *driverv1.Empty) (*driverv1.ShiftList, error) {
+	online, err := d.rdb.HGetAll(ctx, ONLINE_KEY).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(online))
+	for name := range online {
+		names = append(names, name)
+	}
+
+	pipe := d.rdb.Pipeline()
+	seen := make([]*redis.FloatCmd, len(names))
+	for idx, name := range names {
+		seen[idx] = pipe.ZScore(ctx, PRESENCE_KEY, name)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	shifts := &driverv1.ShiftList{
+		Shifts: make([]*driverv1.Shift, len(names)),
+	}
+	for idx, name := range names {
+		startedAt, _ := strconv.ParseInt(online[name], 10, 64)
+		shifts.Shifts[idx] = &driverv1.Shift{
+			Name:      name,
+			StartedAt: startedAt,
+			// presence is kept in seconds
+			LastSeenAt: int64(seen[idx].Val()) * 1000,
+		}
+	}
+
+	return shifts, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/driver/geo"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newShiftEnv(t *testing.T) (*DriverGrpcService, context.Context) {
+	t.Helper()
+
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	d := &DriverGrpcService{log: zap.NewNop(), rdb: rdb, index: geo.NewMemoryIndex()}
+	ctx := interceptor.WithIdentity(context.Background(), interceptor.Identity{Subject: "driver-0", Role: token.RoleDriver})
+
+	return d, ctx
+}
+
+func TestGoOnlineAndOffline(t *testing.T) {
+	d, ctx := newShiftEnv(t)
+
+	online, err := d.GoOnline(ctx, &driverv1.ShiftRequest{Name: "driver-0", Vehicle: driverv1.VehicleType_XL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := d.rdb.Get(ctx, "driver-0").Val(); status != driverv1.DriverStatus_FREE.String() {
+		t.Fatalf("status = %s, want FREE", status)
+	}
+	if vehicle := d.rdb.HGet(ctx, VEHICLES_KEY, "driver-0").Val(); vehicle != driverv1.VehicleType_XL.String() {
+		t.Fatalf("vehicle = %s, want XL", vehicle)
+	}
+
+	// going online again keeps the shift
+	again, err := d.GoOnline(ctx, &driverv1.ShiftRequest{Name: "driver-0", Vehicle: driverv1.VehicleType_XL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.StartedAt != online.StartedAt {
+		t.Fatalf("shift started at %d, then at %d, want the same shift", online.StartedAt, again.StartedAt)
+	}
+
+	offline, err := d.GoOffline(ctx, &driverv1.ShiftRequest{Name: "driver-0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offline.StartedAt != online.StartedAt || offline.EndedAt < offline.StartedAt {
+		t.Fatalf("shift = %+v, want the one started at %d", offline, online.StartedAt)
+	}
+	if status := d.rdb.Get(ctx, "driver-0").Val(); status != "OFFLINE" {
+		t.Fatalf("status = %s, want OFFLINE", status)
+	}
+	if shifts := d.rdb.LLen(ctx, shiftsKey("driver-0")).Val(); shifts != 1 {
+		t.Fatalf("%d shifts in the history, want 1", shifts)
+	}
+
+	if _, err := d.GoOffline(ctx, &driverv1.ShiftRequest{Name: "driver-0"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("GoOffline() = %v, want FailedPrecondition when not online", err)
+	}
+}
+
+func TestGoOfflineOnARide(t *testing.T) {
+	d, ctx := newShiftEnv(t)
+
+	if _, err := d.GoOnline(ctx, &driverv1.ShiftRequest{Name: "driver-0"}); err != nil {
+		t.Fatal(err)
+	}
+	d.rdb.Set(ctx, "driver-0", driverv1.DriverStatus_BUSY.String(), 0)
+
+	if _, err := d.GoOffline(ctx, &driverv1.ShiftRequest{Name: "driver-0"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("GoOffline() = %v, want FailedPrecondition on a ride", err)
+	}
+	if _, err := d.GoOnline(ctx, &driverv1.ShiftRequest{Name: "driver-0"}); err != nil {
+		t.Fatal(err)
+	}
+	if status := d.rdb.Get(ctx, "driver-0").Val(); status != driverv1.DriverStatus_BUSY.String() {
+		t.Fatalf("status = %s, want the driver on a ride to stay BUSY", status)
+	}
+}
+
+func TestListOnline(t *testing.T) {
+	d, ctx := newShiftEnv(t)
+	admin := interceptor.WithIdentity(context.Background(), interceptor.Identity{Subject: "admin", Role: token.RoleAdmin})
+
+	for _, name := range []string{"driver-0", "driver-1", "driver-2"} {
+		if _, err := d.GoOnline(admin, &driverv1.ShiftRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := d.GoOffline(admin, &driverv1.ShiftRequest{Name: "driver-1"}); err != nil {
+		t.Fatal(err)
+	}
+	d.rdb.ZAdd(ctx, PRESENCE_KEY, redis.Z{Score: 1700000000, Member: "driver-0"})
+
+	list, err := d.ListOnline(ctx, &driverv1.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shifts := make(map[string]*driverv1.Shift)
+	for _, shift := range list.Shifts {
+		shifts[shift.Name] = shift
+	}
+	if len(shifts) != 2 || shifts["driver-0"] == nil || shifts["driver-2"] == nil {
+		t.Fatalf("ListOnline() = %v, want driver-0 and driver-2", list.Shifts)
+	}
+	if seen := shifts["driver-0"].LastSeenAt; seen != 1700000000*1000 {
+		t.Fatalf("driver-0 last seen at %d, want %d", seen, 1700000000*1000)
+	}
+	if seen := shifts["driver-2"].LastSeenAt; seen != 0 {
+		t.Fatalf("driver-2 last seen at %d, want never", seen)
+	}
+}
+
+func TestSetStatusFreeRequiresShift(t *testing.T) {
+	d, ctx := newShiftEnv(t)
+
+	free := &driverv1.DriverStatusMetadata{Name: "driver-0", Status: driverv1.DriverStatus_FREE}
+	if _, err := d.SetStatus(ctx, free); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("SetStatus() = %v, want FailedPrecondition when not online", err)
+	}
+	if exists := d.rdb.Exists(ctx, "driver-0").Val(); exists != 0 {
+		t.Fatal("the status of a driver not online was set")
+	}
+
+	if _, err := d.GoOnline(ctx, &driverv1.ShiftRequest{Name: "driver-0"}); err != nil {
+		t.Fatal(err)
+	}
+	busy := &driverv1.DriverStatusMetadata{Name: "driver-0", Status: driverv1.DriverStatus_BUSY}
+	if _, err := d.SetStatus(ctx, busy); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.SetStatus(ctx, free); err != nil {
+		t.Fatal(err)
+	}
+	if status := d.rdb.Get(ctx, "driver-0").Val(); status != driverv1.DriverStatus_FREE.String() {
+		t.Fatalf("status = %s, want FREE", status)
+	}
+}
@@ -0,0 +1,25 @@
+package compass
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name                string
+		lat2, lon2, bearing float64
+	}{
+		{"north", 47.17, 27.59, 0},
+		{"east", 47.16, 27.60, 90},
+		{"south", 47.15, 27.59, 180},
+		{"west", 47.16, 27.58, 270},
+	}
+
+	for _, tt := range tests {
+		// the great circle to a point on the same parallel starts a little off east or west
+		if got := Bearing(47.16, 27.59, tt.lat2, tt.lon2); math.Abs(got-tt.bearing) > 0.01 {
+			t.Errorf("Bearing() to the %s = %f, want %f", tt.name, got, tt.bearing)
+		}
+	}
+}
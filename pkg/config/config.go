@@ -0,0 +1,610 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SECRET_FILE_PREFIX marks a secret read from a file, like file:/run/secrets/redis-password
+const SECRET_FILE_PREFIX = "file:"
+
+// Secret is a value that is never printed, use Value to read it
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "[redacted]"
+}
+
+type Redis struct {
+	Addr     string `yaml:"addr"`
+	Password Secret `yaml:"password"`
+	DB       int    `yaml:"db"`
+}
+
+type GCP struct {
+	Project string `yaml:"project"`
+	// set to run against the local emulators instead of the real services
+	PubSubEmulatorHost    string `yaml:"pubsubEmulatorHost"`
+	FirestoreEmulatorHost string `yaml:"firestoreEmulatorHost"`
+}
+
+type Auth struct {
+	Secret          Secret        `yaml:"secret"`
+	AccessTokenTTL  time.Duration `yaml:"accessTokenTTL"`
+	RefreshTokenTTL time.Duration `yaml:"refreshTokenTTL"`
+	// where the auth service keeps the accounts, memory or redis
+	Store string `yaml:"store"`
+}
+
+type Driver struct {
+	PresenceWindow time.Duration `yaml:"presenceWindow"`
+	ReaperInterval time.Duration `yaml:"reaperInterval"`
+	// a redelivered location is dropped when it was already ingested this long ago at most
+	DedupWindow time.Duration `yaml:"dedupWindow"`
+	// the received locations are written in batches of up to this many, at least every flush interval
+	IngestBatchSize     int           `yaml:"ingestBatchSize"`
+	IngestFlushInterval time.Duration `yaml:"ingestFlushInterval"`
+	// where the drivers are searched, redis or memory for a single driver server
+	GeoIndex string `yaml:"geoIndex"`
+}
+
+type Ride struct {
+	DriverAddr string `yaml:"driverAddr"`
+	// where the ride service keeps the rides, firestore, redis or memory
+	Store        string        `yaml:"store"`
+	OfferTimeout time.Duration `yaml:"offerTimeout"`
+	// matching gives up past this radius, in km
+	MaxSearchRadius float64 `yaml:"maxSearchRadius"`
+	// how much every factor counts when the drivers are ranked
+	Ranking RankWeights `yaml:"ranking"`
+	// greedy matches every ride on its own, batch assigns the drivers to the rides requested in the same window together
+	Dispatch    string        `yaml:"dispatch"`
+	BatchWindow time.Duration `yaml:"batchWindow"`
+	// OpenStreetMap extract the routes are found on, .osm.pbf or .osm, without one the routes are straight lines
+	RouteMap string  `yaml:"routeMap"`
+	Pricing  Pricing `yaml:"pricing"`
+}
+
+// Pricing is how much the rides cost, the amounts are in the major unit of the currency
+type Pricing struct {
+	Currency string `yaml:"currency"`
+	// a ride is priced with the rate cards of the city its pickup is in
+	Cities []City `yaml:"cities"`
+}
+
+type City struct {
+	Name      string  `yaml:"name"`
+	Latitude  float64 `yaml:"latitude"`
+	Longitude float64 `yaml:"longitude"`
+	// km around the center
+	Radius float64 `yaml:"radius"`
+	// the rate card of every vehicle class, standard, comfort or xl
+	Rates map[string]RateCard `yaml:"rates"`
+}
+
+// RateCard prices a ride: the base fare, the km and the minutes make its fare, raised to the minimum fare, and the booking fee is added on top
+type RateCard struct {
+	BaseFare    float64 `yaml:"baseFare"`
+	PerKm       float64 `yaml:"perKm"`
+	PerMinute   float64 `yaml:"perMinute"`
+	MinimumFare float64 `yaml:"minimumFare"`
+	BookingFee  float64 `yaml:"bookingFee"`
+}
+
+// RankWeights weigh the factors of a driver's score, every factor is between 0 and 1
+type RankWeights struct {
+	Distance   float64 `yaml:"distance"`
+	Rating     float64 `yaml:"rating"`
+	Acceptance float64 `yaml:"acceptance"`
+	Idle       float64 `yaml:"idle"`
+	Heading    float64 `yaml:"heading"`
+}
+
+const (
+	MESSAGING_GCP      = "gcp"
+	MESSAGING_EMULATOR = "emulator"
+	MESSAGING_MEMORY   = "memory"
+)
+
+const (
+	GEO_INDEX_REDIS  = "redis"
+	GEO_INDEX_MEMORY = "memory"
+)
+
+const (
+	DISPATCH_GREEDY = "greedy"
+	DISPATCH_BATCH  = "batch"
+)
+
+const (
+	VEHICLE_STANDARD = "standard"
+	VEHICLE_COMFORT  = "comfort"
+	VEHICLE_XL       = "xl"
+)
+
+const (
+	RIDE_STORE_FIRESTORE = "firestore"
+	RIDE_STORE_REDIS     = "redis"
+	RIDE_STORE_MEMORY    = "memory"
+)
+
+// Config is shared by all the services, every service reads the sections it needs
+type Config struct {
+	// the port the grpc server listens on
+	Port int `yaml:"port"`
+	// Local runs without a cloud account, the messages and the rides stay in memory
+	// and redis is embedded in the process unless redis.addr is set
+	Local bool `yaml:"local"`
+	// Messaging is gcp, emulator or memory, by default gcp or memory in local mode
+	Messaging string `yaml:"messaging"`
+	Redis     Redis  `yaml:"redis"`
+	GCP       GCP    `yaml:"gcp"`
+	Auth      Auth   `yaml:"auth"`
+	Driver    Driver `yaml:"driver"`
+	Ride      Ride   `yaml:"ride"`
+}
+
+func Defaults(port int) Config {
+	return Config{
+		Port: port,
+		Auth: Auth{
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+			Store:           "memory",
+		},
+		Driver: Driver{
+			PresenceWindow: 2 * time.Minute,
+			ReaperInterval: 30 * time.Second,
+			DedupWindow:    10 * time.Minute,
+			// a driver reports every few seconds, a tenth of a second later is still fresh
+			IngestBatchSize:     500,
+			IngestFlushInterval: 100 * time.Millisecond,
+			GeoIndex:            GEO_INDEX_REDIS,
+		},
+		Ride: Ride{
+			DriverAddr:      "localhost:8081",
+			OfferTimeout:    15 * time.Second,
+			MaxSearchRadius: 10,
+			Ranking: RankWeights{
+				Distance:   0.7,
+				Rating:     0, // nothing writes the ratings yet, every driver would get the prior
+				Acceptance: 0.1,
+				Idle:       0.1,
+				Heading:    0.1,
+			},
+			Dispatch:    DISPATCH_GREEDY,
+			BatchWindow: 2 * time.Second,
+			Pricing: Pricing{
+				Currency: "RON",
+				Cities: []City{{
+					Name:      "iasi",
+					Latitude:  47.1585,
+					Longitude: 27.6014,
+					Radius:    15,
+					Rates: map[string]RateCard{
+						VEHICLE_STANDARD: {BaseFare: 4, PerKm: 2.2, PerMinute: 0.4, MinimumFare: 10, BookingFee: 1},
+						VEHICLE_COMFORT:  {BaseFare: 5, PerKm: 2.8, PerMinute: 0.5, MinimumFare: 14, BookingFee: 1},
+						VEHICLE_XL:       {BaseFare: 7, PerKm: 3.5, PerMinute: 0.6, MinimumFare: 18, BookingFee: 1},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// field binds a config value to its env var and flag, secrets have no flag so they don't show up in the process list
+type field struct {
+	env   string
+	flag  string
+	usage string
+	ptr   interface{}
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{"PORT", "port", "port of the grpc server", &c.Port},
+		{"LOCAL", "local", "run without a cloud account", &c.Local},
+		{"MESSAGING", "messaging", "messaging, gcp, emulator or memory", &c.Messaging},
+		{"REDIS_ADDR", "redis-addr", "redis address", &c.Redis.Addr},
+		{"REDIS_PASSWORD", "", "", &c.Redis.Password},
+		{"REDIS_DB", "redis-db", "redis database", &c.Redis.DB},
+		{"GCP_PROJECT", "gcp-project", "gcp project of pub/sub and firestore", &c.GCP.Project},
+		{"PUBSUB_EMULATOR_HOST", "pubsub-emulator-host", "address of the pub/sub emulator", &c.GCP.PubSubEmulatorHost},
+		{"FIRESTORE_EMULATOR_HOST", "firestore-emulator-host", "address of the firestore emulator", &c.GCP.FirestoreEmulatorHost},
+		{"AUTH_SECRET", "", "", &c.Auth.Secret},
+		{"ACCESS_TOKEN_TTL", "access-token-ttl", "lifetime of the access tokens", &c.Auth.AccessTokenTTL},
+		{"REFRESH_TOKEN_TTL", "refresh-token-ttl", "lifetime of the refresh tokens", &c.Auth.RefreshTokenTTL},
+		{"AUTH_STORE", "auth-store", "account store, memory or redis", &c.Auth.Store},
+		{"PRESENCE_WINDOW", "presence-window", "drivers not seen for this long are dropped", &c.Driver.PresenceWindow},
+		{"REAPER_INTERVAL", "reaper-interval", "how often stale drivers are dropped", &c.Driver.ReaperInterval},
+		{"DEDUP_WINDOW", "dedup-window", "how long the ingested location ids are remembered", &c.Driver.DedupWindow},
+		{"INGEST_BATCH_SIZE", "ingest-batch-size", "how many locations are written at once", &c.Driver.IngestBatchSize},
+		{"INGEST_FLUSH_INTERVAL", "ingest-flush-interval", "how long a location waits for its batch at most", &c.Driver.IngestFlushInterval},
+		{"GEO_INDEX", "geo-index", "driver search index, redis or memory", &c.Driver.GeoIndex},
+		{"DRIVER_ADDR", "driver-addr", "address of the driver service", &c.Ride.DriverAddr},
+		{"RIDE_STORE", "ride-store", "ride store, firestore, redis or memory", &c.Ride.Store},
+		{"OFFER_TIMEOUT", "offer-timeout", "how long a driver has to answer an offer", &c.Ride.OfferTimeout},
+		{"MAX_SEARCH_RADIUS", "max-search-radius", "matching gives up past this radius, in km", &c.Ride.MaxSearchRadius},
+		{"RANK_DISTANCE_WEIGHT", "rank-distance-weight", "weight of the distance to the pickup in the driver ranking", &c.Ride.Ranking.Distance},
+		{"RANK_RATING_WEIGHT", "rank-rating-weight", "weight of the rating in the driver ranking", &c.Ride.Ranking.Rating},
+		{"RANK_ACCEPTANCE_WEIGHT", "rank-acceptance-weight", "weight of the acceptance rate in the driver ranking", &c.Ride.Ranking.Acceptance},
+		{"RANK_IDLE_WEIGHT", "rank-idle-weight", "weight of the idle time in the driver ranking", &c.Ride.Ranking.Idle},
+		{"RANK_HEADING_WEIGHT", "rank-heading-weight", "weight of the heading towards the pickup in the driver ranking", &c.Ride.Ranking.Heading},
+		{"DISPATCH", "dispatch", "matching, greedy or batch", &c.Ride.Dispatch},
+		{"BATCH_WINDOW", "batch-window", "how long the batch matching collects the rides", &c.Ride.BatchWindow},
+		{"ROUTE_MAP", "route-map", "openstreetmap extract the rides are routed on, .osm.pbf or .osm", &c.Ride.RouteMap},
+		{"CURRENCY", "currency", "currency the rides are priced in", &c.Ride.Pricing.Currency},
+	}
+}
+
+func set(ptr interface{}, value string) error {
+	switch p := ptr.(type) {
+	case *string:
+		*p = value
+	case *Secret:
+		*p = Secret(value)
+	case *bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*p = v
+	case *int:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*p = v
+	case *float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*p = v
+	case *time.Duration:
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*p = v
+	default:
+		return fmt.Errorf("unsupported config type %T", ptr)
+	}
+	return nil
+}
+
+// Check validates the part of the config a service depends on
+type Check func(*Config) error
+
+// Load builds the config from the defaults, the yaml file given by -config or CONFIG_FILE,
+// the env vars and the flags, each one overriding the previous.
+// Secrets starting with file: are read from that file.
+func Load(args []string, defaults Config, checks ...Check) (*Config, error) {
+	cfg := defaults
+	fields := cfg.fields()
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv("CONFIG_FILE"), "path of a yaml config file")
+
+	// flags are applied last, after the file and the env vars
+	type flagValue struct {
+		field field
+		value string
+	}
+	var flags []flagValue
+	for _, f := range fields {
+		if f.flag == "" {
+			continue
+		}
+		f := f
+		fs.Var(&funcValue{
+			set: func(value string) error {
+				flags = append(flags, flagValue{field: f, value: value})
+				return nil
+			},
+			isBool: isBool(f.ptr),
+		}, f.flag, f.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *path != "" {
+		if err := cfg.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		value := os.Getenv(f.env)
+		if value == "" {
+			continue
+		}
+		if err := set(f.ptr, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.env, err)
+		}
+	}
+
+	for _, f := range flags {
+		if err := set(f.field.ptr, f.value); err != nil {
+			return nil, fmt.Errorf("-%s: %w", f.field.flag, err)
+		}
+	}
+
+	for _, f := range fields {
+		secret, ok := f.ptr.(*Secret)
+		if !ok {
+			continue
+		}
+		if err := resolve(secret); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.env, err)
+		}
+	}
+
+	cfg.applyMode()
+
+	if err := cfg.validate(checks); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// funcValue is a flag that records its value, boolean flags can be given without one, like -local
+type funcValue struct {
+	set    func(string) error
+	isBool bool
+}
+
+func (v *funcValue) Set(value string) error {
+	return v.set(value)
+}
+
+func (v *funcValue) String() string {
+	return ""
+}
+
+func (v *funcValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+func isBool(ptr interface{}) bool {
+	_, ok := ptr.(*bool)
+	return ok
+}
+
+// applyMode fills the values whose default depends on running locally or against gcp
+func (c *Config) applyMode() {
+	if c.Messaging == "" {
+		switch {
+		case c.Local:
+			c.Messaging = MESSAGING_MEMORY
+		case c.GCP.PubSubEmulatorHost != "":
+			c.Messaging = MESSAGING_EMULATOR
+		default:
+			c.Messaging = MESSAGING_GCP
+		}
+	}
+
+	if c.Ride.Store == "" {
+		c.Ride.Store = RIDE_STORE_FIRESTORE
+		if c.Local {
+			c.Ride.Store = RIDE_STORE_MEMORY
+		}
+	}
+
+	// an empty address in local mode means an embedded redis
+	if c.Redis.Addr == "" && !c.Local {
+		c.Redis.Addr = "localhost:6379"
+	}
+}
+
+// EmbeddedRedis reports if redis runs inside the process
+func (c *Config) EmbeddedRedis() bool {
+	return c.Local && c.Redis.Addr == ""
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+func resolve(secret *Secret) error {
+	path, ok := strings.CutPrefix(secret.Value(), SECRET_FILE_PREFIX)
+	if !ok {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	*secret = Secret(strings.TrimSpace(string(data)))
+
+	return nil
+}
+
+func (c *Config) validate(checks []Check) error {
+	var errs []error
+	if c.Port <= 0 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port %d is out of range", c.Port))
+	}
+	for _, check := range checks {
+		if err := check(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func RequireRedis(c *Config) error {
+	if c.Redis.Addr == "" && !c.EmbeddedRedis() {
+		return errors.New("REDIS_ADDR is not set")
+	}
+	return nil
+}
+
+// RequireMessaging checks the messaging and the gcp settings it needs
+func RequireMessaging(c *Config) error {
+	switch c.Messaging {
+	case MESSAGING_GCP:
+	case MESSAGING_EMULATOR:
+		if c.GCP.PubSubEmulatorHost == "" {
+			return errors.New("PUBSUB_EMULATOR_HOST is not set")
+		}
+	case MESSAGING_MEMORY:
+		return nil
+	default:
+		return fmt.Errorf("unknown messaging %q", c.Messaging)
+	}
+
+	if c.GCP.Project == "" {
+		return errors.New("GCP_PROJECT is not set")
+	}
+	return nil
+}
+
+// RequireRideStore checks the ride store and the gcp settings firestore needs
+func RequireRideStore(c *Config) error {
+	switch c.Ride.Store {
+	case RIDE_STORE_FIRESTORE:
+		if c.GCP.Project == "" {
+			return errors.New("GCP_PROJECT is not set")
+		}
+	case RIDE_STORE_REDIS, RIDE_STORE_MEMORY:
+	default:
+		return fmt.Errorf("unknown ride store %q", c.Ride.Store)
+	}
+	return nil
+}
+
+// RequireRanking checks the ranking weights, a driver's score must depend on something
+func RequireRanking(c *Config) error {
+	weights := c.Ride.Ranking
+	for _, weight := range []float64{weights.Distance, weights.Rating, weights.Acceptance, weights.Idle, weights.Heading} {
+		if weight < 0 {
+			return errors.New("the ranking weights must not be negative")
+		}
+	}
+	if weights.Distance+weights.Rating+weights.Acceptance+weights.Idle+weights.Heading == 0 {
+		return errors.New("the ranking weights are all 0")
+	}
+	return nil
+}
+
+// RequirePresence checks the presence window and how often the reaper runs
+func RequirePresence(c *Config) error {
+	if c.Driver.PresenceWindow <= 0 {
+		return errors.New("PRESENCE_WINDOW must be positive")
+	}
+	if c.Driver.ReaperInterval <= 0 {
+		return errors.New("REAPER_INTERVAL must be positive")
+	}
+	return nil
+}
+
+// RequireIngest checks how often the received locations are written
+func RequireIngest(c *Config) error {
+	if c.Driver.IngestFlushInterval <= 0 {
+		return errors.New("INGEST_FLUSH_INTERVAL must be positive")
+	}
+	return nil
+}
+
+// RequireMatching checks how far the drivers are searched and how long they have to answer an offer
+func RequireMatching(c *Config) error {
+	if c.Ride.MaxSearchRadius <= 0 {
+		return errors.New("MAX_SEARCH_RADIUS must be positive")
+	}
+	if c.Ride.OfferTimeout <= 0 {
+		return errors.New("OFFER_TIMEOUT must be positive")
+	}
+	return nil
+}
+
+// RequireDispatch checks the matching mode and its window
+func RequireDispatch(c *Config) error {
+	switch c.Ride.Dispatch {
+	case DISPATCH_GREEDY:
+	case DISPATCH_BATCH:
+		if c.Ride.BatchWindow <= 0 {
+			return errors.New("BATCH_WINDOW must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown dispatch %q", c.Ride.Dispatch)
+	}
+	return nil
+}
+
+// RequirePricing checks the rate cards, every city prices at least one vehicle class
+func RequirePricing(c *Config) error {
+	if c.Ride.Pricing.Currency == "" {
+		return errors.New("CURRENCY is not set")
+	}
+	if len(c.Ride.Pricing.Cities) == 0 {
+		return errors.New("no city has rate cards")
+	}
+	for _, city := range c.Ride.Pricing.Cities {
+		if city.Name == "" || city.Radius <= 0 {
+			return fmt.Errorf("city %q needs a name and a positive radius", city.Name)
+		}
+		if len(city.Rates) == 0 {
+			return fmt.Errorf("city %q has no rate cards", city.Name)
+		}
+		for vehicle, card := range city.Rates {
+			switch vehicle {
+			case VEHICLE_STANDARD, VEHICLE_COMFORT, VEHICLE_XL:
+			default:
+				return fmt.Errorf("city %q has a rate card for the unknown vehicle class %q", city.Name, vehicle)
+			}
+			for _, amount := range []float64{card.BaseFare, card.PerKm, card.PerMinute, card.MinimumFare, card.BookingFee} {
+				if amount < 0 {
+					return fmt.Errorf("the %s rate card of city %q has a negative amount", vehicle, city.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RequireAuth fails without a secret, signing tokens with an empty key would let anyone forge them
+func RequireAuth(c *Config) error {
+	if c.Auth.Secret == "" {
+		return errors.New("AUTH_SECRET is not set")
+	}
+	if c.Auth.AccessTokenTTL <= 0 {
+		return errors.New("ACCESS_TOKEN_TTL must be positive")
+	}
+	return nil
+}
+
+// Addr is the address the grpc server listens on
+func (c *Config) Addr() string {
+	return fmt.Sprintf("0.0.0.0:%d", c.Port)
+}
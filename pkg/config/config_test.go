@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+port: 9000
+redis:
+  addr: file-redis:6379
+ride:
+  offerTimeout: 20s
+  maxSearchRadius: 5
+`)
+
+	t.Setenv("REDIS_ADDR", "env-redis:6379")
+	t.Setenv("OFFER_TIMEOUT", "30s")
+
+	cfg, err := Load([]string{"-config", path, "-offer-timeout", "40s"}, Defaults(8081))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Port != 9000 {
+		t.Fatalf("port = %d, want the file's 9000", cfg.Port)
+	}
+	if cfg.Redis.Addr != "env-redis:6379" {
+		t.Fatalf("redis addr = %s, want the env var to override the file", cfg.Redis.Addr)
+	}
+	if cfg.Ride.OfferTimeout != 40*time.Second {
+		t.Fatalf("offer timeout = %s, want the flag to override the env var", cfg.Ride.OfferTimeout)
+	}
+	if cfg.Ride.MaxSearchRadius != 5 {
+		t.Fatalf("max search radius = %f, want 5", cfg.Ride.MaxSearchRadius)
+	}
+	if cfg.Driver.PresenceWindow != 2*time.Minute {
+		t.Fatalf("presence window = %s, want the default", cfg.Driver.PresenceWindow)
+	}
+}
+
+func TestLoadReadsSecretsFromFiles(t *testing.T) {
+	secret := writeFile(t, "auth-secret", "s3cr3t\n")
+	t.Setenv("AUTH_SECRET", SECRET_FILE_PREFIX+secret)
+
+	cfg, err := Load(nil, Defaults(8083), RequireAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Auth.Secret.Value() != "s3cr3t" {
+		t.Fatalf("secret = %q, want the file content", cfg.Auth.Secret.Value())
+	}
+	if printed := fmt.Sprint(cfg.Auth.Secret); strings.Contains(printed, "s3cr3t") {
+		t.Fatalf("secret printed as %q", printed)
+	}
+}
+
+func TestLoadValidates(t *testing.T) {
+	_, err := Load([]string{"-port", "0", "-rank-idle-weight", "-1", "-dispatch", "fifo", "-currency", "", "-reaper-interval", "0s", "-ingest-flush-interval", "-1s", "-max-search-radius", "0"}, Defaults(8082), RequireMessaging, RequirePresence, RequireIngest, RequireRideStore, RequireRanking, RequireMatching, RequireDispatch, RequirePricing, RequireAuth)
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+
+	for _, want := range []string{"port", "GCP_PROJECT", "REAPER_INTERVAL", "INGEST_FLUSH_INTERVAL", "ranking", "MAX_SEARCH_RADIUS", "dispatch", "CURRENCY", "AUTH_SECRET"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q doesn't mention %s", err, want)
+		}
+	}
+}
+
+func TestLoadPricing(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+ride:
+  pricing:
+    cities:
+      - name: cluj
+        latitude: 46.77
+        longitude: 23.6
+        radius: 12
+        rates:
+          standard: {baseFare: 5, perKm: 2.5, perMinute: 0.5, minimumFare: 12, bookingFee: 1.5}
+`)
+
+	cfg, err := Load([]string{"-config", path}, Defaults(8082), RequirePricing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Ride.Pricing.Cities) != 1 || cfg.Ride.Pricing.Cities[0].Name != "cluj" {
+		t.Fatalf("cities = %+v, want the file's to replace the defaults", cfg.Ride.Pricing.Cities)
+	}
+	if cfg.Ride.Pricing.Currency != "RON" {
+		t.Fatalf("currency = %s, want the default", cfg.Ride.Pricing.Currency)
+	}
+
+	path = writeFile(t, "config.yaml", `
+ride:
+  pricing:
+    cities:
+      - name: cluj
+        radius: 12
+        rates:
+          limo: {baseFare: 50}
+`)
+	if _, err := Load([]string{"-config", path}, Defaults(8082), RequirePricing); err == nil || !strings.Contains(err.Error(), "limo") {
+		t.Fatalf("err = %v, want the unknown vehicle class", err)
+	}
+}
+
+func TestLoadRejectsUnknownFileKeys(t *testing.T) {
+	path := writeFile(t, "config.yaml", "redis:\n  adress: typo:6379\n")
+
+	if _, err := Load([]string{"-config", path}, Defaults(8081)); err == nil {
+		t.Fatal("expected an error for the misspelled key")
+	}
+}
+
+func TestLoadLocalMode(t *testing.T) {
+	cfg, err := Load([]string{"--local"}, Defaults(8082), RequireRedis, RequireMessaging, RequireRideStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Messaging != MESSAGING_MEMORY || cfg.Ride.Store != RIDE_STORE_MEMORY || !cfg.EmbeddedRedis() {
+		t.Fatalf("local mode = %s %s embedded redis %t, want everything in memory", cfg.Messaging, cfg.Ride.Store, cfg.EmbeddedRedis())
+	}
+
+	t.Setenv("PUBSUB_EMULATOR_HOST", "localhost:8085")
+	t.Setenv("GCP_PROJECT", "local")
+
+	cfg, err = Load(nil, Defaults(8082), RequireRedis, RequireMessaging)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Messaging != MESSAGING_EMULATOR || cfg.Redis.Addr != "localhost:6379" {
+		t.Fatalf("messaging = %s, redis = %s, want the emulator and the local redis", cfg.Messaging, cfg.Redis.Addr)
+	}
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+)
+
+// the attributes a dead letter carries on top of the ones of the original message
+const (
+	DEAD_LETTER_PREFIX       = "deadletter."
+	DEAD_LETTER_ERROR        = DEAD_LETTER_PREFIX + "error"
+	DEAD_LETTER_SUBSCRIPTION = DEAD_LETTER_PREFIX + "subscription"
+	DEAD_LETTER_MESSAGE_ID   = DEAD_LETTER_PREFIX + "messageid"
+	DEAD_LETTER_ATTEMPTS     = DEAD_LETTER_PREFIX + "attempts"
+	DEAD_LETTER_TIME         = DEAD_LETTER_PREFIX + "time"
+)
+
+// DeadLetterTopic holds the messages a consumer gave up on, untouched, so they can be inspected and replayed
+type DeadLetterTopic struct {
+	Name         string
+	Subscription string
+	// the topic the messages are replayed to
+	Source string
+}
+
+func (t DeadLetterTopic) TopicName() string {
+	return t.Name
+}
+
+func (t DeadLetterTopic) SubscriptionName() string {
+	return t.Subscription
+}
+
+// Send publishes the message to the dead letter topic together with the reason it was given up on
+func (t DeadLetterTopic) Send(ctx context.Context, p messaging.Publisher, subscription string, m *messaging.Message, reason error) (string, error) {
+	attributes := make(map[string]string, len(m.Attributes)+5)
+	for key, value := range m.Attributes {
+		attributes[key] = value
+	}
+	attributes[DEAD_LETTER_ERROR] = reason.Error()
+	attributes[DEAD_LETTER_SUBSCRIPTION] = subscription
+	attributes[DEAD_LETTER_MESSAGE_ID] = m.ID
+	attributes[DEAD_LETTER_ATTEMPTS] = strconv.Itoa(m.DeliveryAttempt)
+	attributes[DEAD_LETTER_TIME] = time.Now().UTC().Format(time.RFC3339)
+
+	return p.Publish(ctx, t.Name, m.Data, attributes)
+}
+
+// Replay publishes a dead letter back to the source topic as it was first received
+func (t DeadLetterTopic) Replay(ctx context.Context, p messaging.Publisher, m *messaging.Message) (string, error) {
+	attributes := make(map[string]string, len(m.Attributes))
+	for key, value := range m.Attributes {
+		if !strings.HasPrefix(key, DEAD_LETTER_PREFIX) {
+			attributes[key] = value
+		}
+	}
+
+	return p.Publish(ctx, t.Source, m.Data, attributes)
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWrongType          = errors.New("Wrong event type")
+	ErrUnsupportedVersion = errors.New("Unsupported event version")
+)
+
+// Envelope wraps every event on the wire, the data is decoded according to the type and the version.
+// Payloads sent before the envelope existed decode as version 0.
+type Envelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	ID      string          `json:"id"`
+	Time    time.Time       `json:"time"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Topic ties a topic to the one event type it carries, so publishers and subscribers can't disagree on it.
+// Adding a field is not a new version, renaming or removing one is:
+// bump Version and convert the old payloads in Upgrade.
+type Topic[T any] struct {
+	Name string
+	// the subscription the services read the topic from, empty when nobody subscribes
+	Subscription string
+	Type         string
+	Version      int
+	// Upgrade decodes the payloads of the older versions, they are decoded as the current one when it is nil
+	Upgrade func(version int, data json.RawMessage) (T, error)
+}
+
+func (t Topic[T]) TopicName() string {
+	return t.Name
+}
+
+func (t Topic[T]) SubscriptionName() string {
+	return t.Subscription
+}
+
+// Encode wraps the event in an envelope of the current version
+func (t Topic[T]) Encode(event T) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Envelope{
+		Type:    t.Type,
+		Version: t.Version,
+		ID:      uuid.New().String(),
+		Time:    time.Now().UTC(),
+		Data:    data,
+	})
+}
+
+// Decode unwraps the event, payloads without an envelope are decoded as version 0
+func (t Topic[T]) Decode(raw []byte) (T, Envelope, error) {
+	var (
+		event    T
+		envelope Envelope
+	)
+
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return event, envelope, err
+	}
+	if envelope.Type == "" && envelope.Data == nil {
+		envelope = Envelope{Type: t.Type, Data: raw}
+	}
+
+	if envelope.Type != t.Type {
+		return event, envelope, fmt.Errorf("%w: %s on topic %s", ErrWrongType, envelope.Type, t.Name)
+	}
+	if envelope.Version > t.Version {
+		return event, envelope, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, envelope.Type, envelope.Version)
+	}
+
+	if envelope.Version < t.Version && t.Upgrade != nil {
+		event, err := t.Upgrade(envelope.Version, envelope.Data)
+		return event, envelope, err
+	}
+
+	err := json.Unmarshal(envelope.Data, &event)
+	return event, envelope, err
+}
+
+// Publish sends the event on its topic, the type and the version are also set as attributes for filtering
+func Publish[T any](ctx context.Context, p messaging.Publisher, topic Topic[T], event T) (string, error) {
+	data, err := topic.Encode(event)
+	if err != nil {
+		return "", err
+	}
+
+	return p.Publish(ctx, topic.Name, data, map[string]string{
+		"type":    topic.Type,
+		"version": strconv.Itoa(topic.Version),
+	})
+}
+
+// Delivery is a received event together with the message that carried it
+type Delivery[T any] struct {
+	*messaging.Message
+
+	Envelope Envelope
+	Event    T
+}
+
+// Subscribe decodes the events of the topic's subscription, err is set when the message can't be decoded.
+// The handler acks or nacks every delivery.
+func Subscribe[T any](ctx context.Context, s messaging.Subscriber, topic Topic[T], handler func(context.Context, *Delivery[T], error)) error {
+	return s.Receive(ctx, topic.Subscription, func(ctx context.Context, m *messaging.Message) {
+		event, envelope, err := topic.Decode(m.Data)
+		handler(ctx, &Delivery[T]{Message: m, Envelope: envelope, Event: event}, err)
+	})
+}
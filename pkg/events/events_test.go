@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	want := DriverLocation{ID: "driver-1", Coords: Coords{Latitude: 47.16, Longitude: 27.59}}
+
+	data, err := DriverLocations.Encode(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, envelope, err := DriverLocations.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("decoded %+v, want %+v", got, want)
+	}
+	if envelope.Type != DriverLocations.Type || envelope.Version != DriverLocations.Version || envelope.ID == "" {
+		t.Fatalf("unexpected envelope %+v", envelope)
+	}
+}
+
+// the drivers' devices published the bare payload before the envelope existed
+func TestDecodeLegacyPayload(t *testing.T) {
+	got, envelope, err := DriverLocations.Decode([]byte(`{"id":"driver-1","coords":{"latitude":47.16,"longitude":27.59}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "driver-1" || got.Coords.Latitude != 47.16 || got.Coords.Longitude != 27.59 {
+		t.Fatalf("decoded %+v", got)
+	}
+	if envelope.Version != 0 {
+		t.Fatalf("legacy payload decoded as version %d", envelope.Version)
+	}
+}
+
+func TestDecodeRejectsOtherTypesAndNewerVersions(t *testing.T) {
+	data, err := RideNotifications.Encode(RideNotification{Event: RIDE_COMPLETED})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := DriverLocations.Decode(data); !errors.Is(err, ErrWrongType) {
+		t.Fatalf("decoding a notification as a location returned %v", err)
+	}
+
+	newer, _ := json.Marshal(Envelope{Type: DriverLocations.Type, Version: DriverLocations.Version + 1, Data: []byte(`{}`)})
+	if _, _, err := DriverLocations.Decode(newer); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("decoding a newer version returned %v", err)
+	}
+}
+
+func TestDecodeUpgradesOlderVersions(t *testing.T) {
+	topic := DriverLocations
+	topic.Version = 2
+	topic.Upgrade = func(version int, data json.RawMessage) (DriverLocation, error) {
+		var old struct {
+			Name string `json:"name"`
+		}
+		err := json.Unmarshal(data, &old)
+		return DriverLocation{ID: old.Name}, err
+	}
+
+	old, _ := json.Marshal(Envelope{Type: topic.Type, Version: 1, Data: []byte(`{"name":"driver-1"}`)})
+	got, _, err := topic.Decode(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "driver-1" {
+		t.Fatalf("upgraded %+v", got)
+	}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	broker := messaging.NewMemory(Topology())
+
+	_, err := Publish(ctx, broker, DriverLocations, DriverLocation{ID: "driver-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := make(chan DriverLocation, 1)
+	go Subscribe(ctx, broker, DriverLocations, func(ctx context.Context, d *Delivery[DriverLocation], err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		d.Ack()
+		received <- d.Event
+	})
+
+	select {
+	case event := <-received:
+		if event.ID != "driver-1" {
+			t.Fatalf("received %+v", event)
+		}
+	case <-ctx.Done():
+		t.Fatal("no event received")
+	}
+}
+
+func TestDeadLetterReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	broker := messaging.NewMemory(Topology())
+
+	original := &messaging.Message{ID: "1", Data: []byte("not json"), Attributes: map[string]string{"type": "driver.location"}, DeliveryAttempt: 5}
+	if _, err := DriverLocationsDLQ.Send(ctx, broker, DriverLocations.Subscription, original, errors.New("invalid payload")); err != nil {
+		t.Fatal(err)
+	}
+
+	letters := make(chan *messaging.Message, 1)
+	go broker.Receive(ctx, DriverLocationsDLQ.Subscription, func(ctx context.Context, m *messaging.Message) {
+		m.Ack()
+		letters <- m
+	})
+
+	var letter *messaging.Message
+	select {
+	case letter = <-letters:
+	case <-ctx.Done():
+		t.Fatal("no dead letter received")
+	}
+	if letter.Attributes[DEAD_LETTER_ERROR] != "invalid payload" || letter.Attributes[DEAD_LETTER_ATTEMPTS] != "5" || letter.Attributes[DEAD_LETTER_MESSAGE_ID] != "1" {
+		t.Fatalf("dead letter attributes %v", letter.Attributes)
+	}
+
+	if _, err := DriverLocationsDLQ.Replay(ctx, broker, letter); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := make(chan *messaging.Message, 1)
+	go broker.Receive(ctx, DriverLocations.Subscription, func(ctx context.Context, m *messaging.Message) {
+		m.Ack()
+		replayed <- m
+	})
+
+	select {
+	case m := <-replayed:
+		if string(m.Data) != "not json" || len(m.Attributes) != 1 || m.Attributes["type"] != "driver.location" {
+			t.Fatalf("replayed %q %v", m.Data, m.Attributes)
+		}
+	case <-ctx.Done():
+		t.Fatal("the dead letter was not replayed")
+	}
+}
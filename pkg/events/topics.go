@@ -0,0 +1,93 @@
+package events
+
+import (
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+)
+
+type Coords struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// DriverLocation is published by the drivers' devices every few seconds
+type DriverLocation struct {
+	ID     string `json:"id"`
+	Coords Coords `json:"coords"`
+	// when the device took the location, the older devices don't send it
+	Timestamp time.Time `json:"timestamp"`
+	// counts the locations sent by the device, it orders the locations taken in the same millisecond
+	Sequence uint64 `json:"seq"`
+	// degrees clockwise from north, not sent by the devices that don't know it
+	Heading *float64 `json:"heading,omitempty"`
+}
+
+const (
+	RIDE_COMPLETED = "completed"
+	RIDE_CANCELLED = "cancelled"
+)
+
+// RideNotification is published when a ride ends
+type RideNotification struct {
+	Event      string    `json:"event"`
+	RideID     string    `json:"rideid"`
+	RiderName  string    `json:"rider"`
+	DriverName string    `json:"driver"`
+	Distance   float64   `json:"distance"`
+	Fare       int64     `json:"fare,omitempty"`
+	Currency   string    `json:"currency,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+var (
+	DriverLocations = Topic[DriverLocation]{
+		Name:         "rider-streaming",
+		Subscription: "rider-streaming-sub",
+		Type:         "driver.location",
+		Version:      1,
+	}
+
+	// DriverLocationsDLQ holds the locations the driver servers could not ingest
+	DriverLocationsDLQ = DeadLetterTopic{
+		Name:         "rider-streaming-dlq",
+		Subscription: "rider-streaming-dlq-sub",
+		Source:       "rider-streaming",
+	}
+
+	RideNotifications = Topic[RideNotification]{
+		Name:    "notification-stream",
+		Type:    "ride.notification",
+		Version: 1,
+	}
+)
+
+type registered interface {
+	TopicName() string
+	SubscriptionName() string
+}
+
+// registry lists every topic, a topic missing from it is not created by the emulator or the in-memory broker
+var registry = []registered{
+	DriverLocations,
+	DriverLocationsDLQ,
+	RideNotifications,
+}
+
+// Topology is the topics and subscriptions of the registry
+func Topology() messaging.Topology {
+	topology := messaging.Topology{
+		Subscriptions: make(map[string]string),
+	}
+
+	for _, topic := range registry {
+		topology.Topics = append(topology.Topics, topic.TopicName())
+		if topic.SubscriptionName() != "" {
+			topology.Subscriptions[topic.SubscriptionName()] = topic.TopicName()
+		}
+	}
+
+	return topology
+}
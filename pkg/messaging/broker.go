@@ -0,0 +1,51 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Open picks the broker from the config
+func Open(ctx context.Context, cfg *config.Config, topology Topology) (Broker, error) {
+	switch cfg.Messaging {
+	case config.MESSAGING_GCP:
+		return NewGCP(ctx, cfg.GCP.Project)
+	case config.MESSAGING_EMULATOR:
+		return NewEmulator(ctx, cfg.GCP.Project, cfg.GCP.PubSubEmulatorHost, topology)
+	case config.MESSAGING_MEMORY:
+		return NewMemory(topology), nil
+	default:
+		return nil, fmt.Errorf("unknown messaging %q", cfg.Messaging)
+	}
+}
+
+// NewBroker opens the broker picked by the config and closes it when the app stops
+func NewBroker(lc fx.Lifecycle, log *zap.Logger, cfg *config.Config, topology Topology) (Broker, error) {
+	broker, err := Open(context.Background(), cfg, topology)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Using messaging", zap.String("messaging", cfg.Messaging))
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return broker.Close()
+		},
+	})
+
+	return broker, nil
+}
+
+// AsPublisher and AsSubscriber let fx hand out the broker to constructors that only publish or only receive
+func AsPublisher(b Broker) Publisher {
+	return b
+}
+
+func AsSubscriber(b Broker) Subscriber {
+	return b
+}
@@ -0,0 +1,163 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// RETRY_MIN_BACKOFF and RETRY_MAX_BACKOFF bound how long pub/sub waits before delivering a nacked message again
+const (
+	RETRY_MIN_BACKOFF = time.Second
+	RETRY_MAX_BACKOFF = time.Minute
+	// ATTEMPTS_TTL is how long the deliveries of a message are counted after the last one,
+	// a nacked message redelivered to another instance is never acked here
+	ATTEMPTS_TTL = 10 * time.Minute
+)
+
+// GCPBroker sends the messages through google cloud pub/sub
+type GCPBroker struct {
+	client *pubsub.Client
+
+	mu sync.Mutex
+	// pub/sub only counts the deliveries on subscriptions with a dead letter policy,
+	// on the others they are counted here, by message id, until the message is acked or ATTEMPTS_TTL passes
+	attempts map[string]*attempts
+	swept    time.Time
+	now      func() time.Time
+}
+
+// attempts counts the deliveries of a message to this instance
+type attempts struct {
+	count int
+	last  time.Time
+}
+
+func NewGCP(ctx context.Context, project string, opts ...option.ClientOption) (*GCPBroker, error) {
+	client, err := pubsub.NewClient(ctx, project, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GCPBroker{
+		client:   client,
+		attempts: make(map[string]*attempts),
+		now:      time.Now,
+	}, nil
+}
+
+// NewEmulator connects to the pub/sub emulator and creates the topics and subscriptions,
+// the emulator starts empty every time
+func NewEmulator(ctx context.Context, project, host string, topology Topology) (*GCPBroker, error) {
+	b, err := NewGCP(
+		ctx,
+		project,
+		option.WithEndpoint(host),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.createTopology(ctx, topology); err != nil {
+		b.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (b *GCPBroker) createTopology(ctx context.Context, topology Topology) error {
+	for _, name := range topology.Topics {
+		_, err := b.client.CreateTopic(ctx, name)
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return err
+		}
+	}
+
+	for subscription, topic := range topology.Subscriptions {
+		_, err := b.client.CreateSubscription(ctx, subscription, pubsub.SubscriptionConfig{
+			Topic: b.client.Topic(topic),
+			RetryPolicy: &pubsub.RetryPolicy{
+				MinimumBackoff: RETRY_MIN_BACKOFF,
+				MaximumBackoff: RETRY_MAX_BACKOFF,
+			},
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *GCPBroker) Publish(ctx context.Context, topic string, data []byte, attributes map[string]string) (string, error) {
+	res := b.client.Topic(topic).Publish(ctx, &pubsub.Message{
+		Data:       data,
+		Attributes: attributes,
+	})
+	return res.Get(ctx)
+}
+
+func (b *GCPBroker) Receive(ctx context.Context, subscription string, handler func(context.Context, *Message)) error {
+	return b.client.Subscription(subscription).Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
+		handler(ctx, &Message{
+			ID:              m.ID,
+			Data:            m.Data,
+			Attributes:      m.Attributes,
+			PublishTime:     m.PublishTime,
+			DeliveryAttempt: b.deliveryAttempt(m),
+			ack: func() {
+				b.forget(m.ID)
+				m.Ack()
+			},
+			nack: m.Nack,
+		})
+	})
+}
+
+func (b *GCPBroker) deliveryAttempt(m *pubsub.Message) int {
+	if m.DeliveryAttempt != nil {
+		return *m.DeliveryAttempt
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if now.Sub(b.swept) > ATTEMPTS_TTL {
+		for id, counted := range b.attempts {
+			if now.Sub(counted.last) > ATTEMPTS_TTL {
+				delete(b.attempts, id)
+			}
+		}
+		b.swept = now
+	}
+
+	counted, ok := b.attempts[m.ID]
+	if !ok || now.Sub(counted.last) > ATTEMPTS_TTL {
+		counted = &attempts{}
+		b.attempts[m.ID] = counted
+	}
+	counted.count++
+	counted.last = now
+	return counted.count
+}
+
+func (b *GCPBroker) forget(id string) {
+	b.mu.Lock()
+	delete(b.attempts, id)
+	b.mu.Unlock()
+}
+
+func (b *GCPBroker) Close() error {
+	return b.client.Close()
+}
@@ -0,0 +1,113 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+)
+
+func TestEmulatorCreatesTopology(t *testing.T) {
+	server := pstest.NewServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	b, err := NewEmulator(ctx, "local", server.Addr, testTopology)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	// a second service starting against the same emulator finds the topology in place
+	other, err := NewEmulator(ctx, "local", server.Addr, testTopology)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.Close()
+
+	if _, err := b.Publish(ctx, "rider-streaming", []byte("hello"), map[string]string{"version": "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	received := make(chan *Message, 1)
+	receiveCtx, stop := context.WithCancel(ctx)
+	go b.Receive(receiveCtx, "rider-streaming-sub", func(ctx context.Context, m *Message) {
+		m.Ack()
+		received <- m
+	})
+	defer stop()
+
+	select {
+	case m := <-received:
+		if string(m.Data) != "hello" || m.Attributes["version"] != "1" {
+			t.Fatalf("received %q %v", m.Data, m.Attributes)
+		}
+	case <-ctx.Done():
+		t.Fatal("no message received")
+	}
+}
+
+func TestGCPBrokerCountsDeliveryAttempts(t *testing.T) {
+	server := pstest.NewServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	b, err := NewEmulator(ctx, "local", server.Addr, testTopology)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	if _, err := b.Publish(ctx, "rider-streaming", []byte("hello"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := make(chan int, 3)
+	receiveCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go b.Receive(receiveCtx, "rider-streaming-sub", func(ctx context.Context, m *Message) {
+		attempts <- m.DeliveryAttempt
+		if m.DeliveryAttempt < 3 {
+			m.Nack()
+			return
+		}
+		m.Ack()
+	})
+
+	for want := 1; want <= 3; want++ {
+		select {
+		case got := <-attempts:
+			if got != want {
+				t.Fatalf("delivery attempt = %d, want %d", got, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("delivery %d not received", want)
+		}
+	}
+}
+
+func TestGCPBrokerForgetsTheAttemptsOfMessagesGoneElsewhere(t *testing.T) {
+	now := time.Now()
+	b := &GCPBroker{attempts: make(map[string]*attempts), now: func() time.Time { return now }}
+
+	b.deliveryAttempt(&pubsub.Message{ID: "redelivered elsewhere"})
+	if got := b.deliveryAttempt(&pubsub.Message{ID: "redelivered elsewhere"}); got != 2 {
+		t.Fatalf("delivery attempt = %d, want 2", got)
+	}
+
+	// the message went to another instance and was acked there
+	now = now.Add(ATTEMPTS_TTL + time.Second)
+	b.deliveryAttempt(&pubsub.Message{ID: "other"})
+	if _, ok := b.attempts["redelivered elsewhere"]; ok || len(b.attempts) != 1 {
+		t.Fatalf("%d messages counted, want only the last one", len(b.attempts))
+	}
+	if got := b.deliveryAttempt(&pubsub.Message{ID: "redelivered elsewhere"}); got != 1 {
+		t.Fatalf("delivery attempt = %d, want the count to start over", got)
+	}
+}
@@ -0,0 +1,121 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrUnknownSubscription = errors.New("Unknown subscription")
+
+// MemoryBroker delivers the messages inside the process, for tests and for running without a cloud account
+type MemoryBroker struct {
+	mu     sync.Mutex
+	nextID int
+	// the queues of the subscriptions, by subscription name
+	queues map[string]chan *Message
+	// the subscriptions of every topic
+	topics map[string][]string
+}
+
+const (
+	// MEMORY_QUEUE_SIZE is how many unhandled messages a subscription holds before Publish blocks
+	MEMORY_QUEUE_SIZE = 1024
+	// a nacked message is delivered again after a backoff doubling with every attempt, like on pub/sub
+	MEMORY_MIN_BACKOFF = 10 * time.Millisecond
+	MEMORY_MAX_BACKOFF = time.Second
+)
+
+func NewMemory(topology Topology) *MemoryBroker {
+	b := &MemoryBroker{
+		queues: make(map[string]chan *Message),
+		topics: make(map[string][]string),
+	}
+
+	for subscription, topic := range topology.Subscriptions {
+		b.queues[subscription] = make(chan *Message, MEMORY_QUEUE_SIZE)
+		b.topics[topic] = append(b.topics[topic], subscription)
+	}
+
+	return b
+}
+
+// messages published to a topic without subscriptions are dropped, like on pub/sub
+func (b *MemoryBroker) Publish(ctx context.Context, topic string, data []byte, attributes map[string]string) (string, error) {
+	b.mu.Lock()
+	b.nextID++
+	id := strconv.Itoa(b.nextID)
+	subscriptions := b.topics[topic]
+	b.mu.Unlock()
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		select {
+		case b.queues[subscription] <- &Message{ID: id, Data: data, Attributes: attributes, PublishTime: now, DeliveryAttempt: 1}:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	return id, nil
+}
+
+func (b *MemoryBroker) Receive(ctx context.Context, subscription string, handler func(context.Context, *Message)) error {
+	queue, ok := b.queues[subscription]
+	if !ok {
+		return ErrUnknownSubscription
+	}
+
+	for {
+		select {
+		case m := <-queue:
+			go b.deliver(ctx, queue, m, handler)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (b *MemoryBroker) deliver(ctx context.Context, queue chan *Message, m *Message, handler func(context.Context, *Message)) {
+	var once sync.Once
+	delivered := &Message{
+		ID:              m.ID,
+		Data:            m.Data,
+		Attributes:      m.Attributes,
+		PublishTime:     m.PublishTime,
+		DeliveryAttempt: m.DeliveryAttempt,
+		ack:             func() { once.Do(func() {}) },
+		nack: func() {
+			once.Do(func() {
+				redelivered := *m
+				redelivered.DeliveryAttempt++
+				time.AfterFunc(backoff(m.DeliveryAttempt), func() {
+					select {
+					case queue <- &redelivered:
+					case <-ctx.Done():
+					}
+				})
+			})
+		},
+	}
+
+	handler(ctx, delivered)
+}
+
+// backoff is how long a message nacked on the given attempt waits before it is delivered again
+func backoff(attempt int) time.Duration {
+	wait := MEMORY_MIN_BACKOFF
+	for n := 1; n < attempt && wait < MEMORY_MAX_BACKOFF; n++ {
+		wait *= 2
+	}
+	if wait > MEMORY_MAX_BACKOFF {
+		wait = MEMORY_MAX_BACKOFF
+	}
+	return wait
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
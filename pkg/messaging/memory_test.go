@@ -0,0 +1,90 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testTopology = Topology{
+	Topics:        []string{"rider-streaming", "notification-stream"},
+	Subscriptions: map[string]string{"rider-streaming-sub": "rider-streaming"},
+}
+
+func TestMemoryBrokerRedeliversNackedMessages(t *testing.T) {
+	b := NewMemory(testTopology)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := b.Publish(ctx, "rider-streaming", []byte("hello"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu         sync.Mutex
+		deliveries int
+		nacked     time.Time
+	)
+	done := make(chan struct{})
+
+	go b.Receive(ctx, "rider-streaming-sub", func(ctx context.Context, m *Message) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		deliveries++
+		if string(m.Data) != "hello" {
+			t.Errorf("data = %q, want hello", m.Data)
+		}
+		if m.DeliveryAttempt != deliveries {
+			t.Errorf("delivery attempt = %d, want %d", m.DeliveryAttempt, deliveries)
+		}
+		if deliveries == 1 {
+			nacked = time.Now()
+			m.Nack()
+			return
+		}
+		if waited := time.Since(nacked); waited < MEMORY_MIN_BACKOFF {
+			t.Errorf("delivered again after %s, want a backoff of %s", waited, MEMORY_MIN_BACKOFF)
+		}
+		m.Ack()
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("the nacked message was not delivered again")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, MEMORY_MIN_BACKOFF},
+		{2, 2 * MEMORY_MIN_BACKOFF},
+		{4, 8 * MEMORY_MIN_BACKOFF},
+		{20, MEMORY_MAX_BACKOFF},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryBrokerDropsMessagesWithoutSubscriptions(t *testing.T) {
+	b := NewMemory(testTopology)
+
+	id, err := b.Publish(context.Background(), "notification-stream", []byte("{}"), nil)
+	if err != nil || id == "" {
+		t.Fatalf("publish = %q %v, want an id", id, err)
+	}
+
+	if err := b.Receive(context.Background(), "unknown", nil); err != ErrUnknownSubscription {
+		t.Fatalf("receive from an unknown subscription returned %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// Topology is created by the emulator and the in-memory broker on start, on gcp it is managed outside the services
+type Topology struct {
+	Topics []string
+	// the topic of every subscription, by subscription name
+	Subscriptions map[string]string
+}
+
+type Message struct {
+	ID          string
+	Data        []byte
+	Attributes  map[string]string
+	PublishTime time.Time
+	// DeliveryAttempt counts the deliveries of the message, starting at 1
+	DeliveryAttempt int
+
+	ack  func()
+	nack func()
+}
+
+// Ack marks the message as handled, it is not delivered again
+func (m *Message) Ack() {
+	if m.ack != nil {
+		m.ack()
+	}
+}
+
+// Nack asks for the message to be delivered again later
+func (m *Message) Nack() {
+	if m.nack != nil {
+		m.nack()
+	}
+}
+
+type Publisher interface {
+	// Publish returns the id the message got once it is stored by the broker
+	Publish(ctx context.Context, topic string, data []byte, attributes map[string]string) (string, error)
+}
+
+type Subscriber interface {
+	// Receive calls handler for every message of the subscription until ctx is done,
+	// handler is called concurrently and must Ack or Nack every message
+	Receive(ctx context.Context, subscription string, handler func(context.Context, *Message)) error
+}
+
+type Broker interface {
+	Publisher
+	Subscriber
+	Close() error
+}
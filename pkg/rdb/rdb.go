@@ -0,0 +1,47 @@
+package rdb
+
+import (
+	"context"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// New connects to redis, in local mode without an address it starts an embedded redis
+// that lives as long as the app, the data is lost on restart
+func New(lc fx.Lifecycle, log *zap.Logger, cfg *config.Config) (*redis.Client, error) {
+	addr := cfg.Redis.Addr
+
+	if cfg.EmbeddedRedis() {
+		embedded, err := miniredis.Run()
+		if err != nil {
+			return nil, err
+		}
+		addr = embedded.Addr()
+
+		lc.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				embedded.Close()
+				return nil
+			},
+		})
+		log.Info("Using embedded redis", zap.String("addr", addr))
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: cfg.Redis.Password.Value(),
+		DB:       cfg.Redis.DB,
+	})
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return client.Close()
+		},
+	})
+
+	return client, nil
+}
@@ -3,8 +3,9 @@ module github.com/alexcogojocaru/cloud-computing-project/ride/client
 go 1.20
 
 require (
+	github.com/alexcogojocaru/cloud-computing-project/api v0.0.0-00010101000000-000000000000
+	github.com/google/uuid v1.3.0
 	google.golang.org/grpc v1.55.0
-	google.golang.org/protobuf v1.30.0
 )
 
 require (
@@ -13,4 +14,7 @@ require (
 	golang.org/x/sys v0.6.0 // indirect
 	golang.org/x/text v0.8.0 // indirect
 	google.golang.org/genproto v0.0.0-20230306155012-7f2fa6fef1f4 // indirect
+	google.golang.org/protobuf v1.30.0 // indirect
 )
+
+replace github.com/alexcogojocaru/cloud-computing-project/api => ../../api
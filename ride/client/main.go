@@ -4,37 +4,81 @@ import (
 	"context"
 	"io"
 	"log"
+	"os"
 
-	"github.com/alexcogojocaru/cloud-computing-project/ride/client/pb"
+	authv1 "github.com/alexcogojocaru/cloud-computing-project/api/auth/v1"
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	ridev1 "github.com/alexcogojocaru/cloud-computing-project/api/ride/v1"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	RIDE_SERVICE_ADDR = os.Getenv("RIDE_SERVICE_ADDR")
+	AUTH_SERVICE_ADDR = os.Getenv("AUTH_SERVICE_ADDR")
+	// a new account is registered when the credentials are not set
+	RIDER_USERNAME = os.Getenv("RIDER_USERNAME")
+	RIDER_PASSWORD = os.Getenv("RIDER_PASSWORD")
 )
 
 func main() {
-	conn, err := grpc.Dial("localhost:8082", grpc.WithInsecure())
+	if RIDE_SERVICE_ADDR == "" {
+		RIDE_SERVICE_ADDR = "localhost:8082"
+	}
+	if AUTH_SERVICE_ADDR == "" {
+		AUTH_SERVICE_ADDR = "localhost:8083"
+	}
+	if RIDER_USERNAME == "" {
+		RIDER_USERNAME = uuid.New().String()
+		RIDER_PASSWORD = uuid.New().String()
+	}
+
+	accessToken, err := login(RIDER_USERNAME, RIDER_PASSWORD)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(RIDE_SERVICE_ADDR, grpc.WithInsecure())
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer conn.Close()
 
-	client := pb.NewRideClient(conn)
-
-	stream, err := client.Start(context.Background(), &pb.StartRideRequest{
-		Username: "alexcogojocaru",
-		StartLocation: &pb.LocationMetadata{
-			Latitude:  47.16129960502986,
-			Longitude: 27.590637972547764,
-			Radius:    2,
-		},
-		EndLocation: &pb.LocationMetadata{
-			Latitude:  47.172983080034896,
-			Longitude: 27.54453466623929,
-			Radius:    2,
-		},
+	client := ridev1.NewRideClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+accessToken)
+	start := &driverv1.LocationMetadata{
+		Latitude:  47.16129960502986,
+		Longitude: 27.590637972547764,
+		Radius:    2,
+	}
+	end := &driverv1.LocationMetadata{
+		Latitude:  47.172983080034896,
+		Longitude: 27.54453466623929,
+		Radius:    2,
+	}
+
+	estimate, err := client.Estimate(ctx, &ridev1.EstimateRequest{StartLocation: start, EndLocation: end})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, fare := range estimate.Fares {
+		log.Printf("estimate %s %.2fkm eta=%ds fare=%.2f %s\n", fare.Vehicle, estimate.Route.Distance, estimate.Route.EtaSeconds, float64(fare.Total)/100, fare.Currency)
+	}
+
+	stream, err := client.Start(ctx, &ridev1.StartRideRequest{
+		Username:      RIDER_USERNAME,
+		StartLocation: start,
+		EndLocation:   end,
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	var last *ridev1.StartRideResponse
 	for {
 		resp, err := stream.Recv()
 		if err == io.EOF {
@@ -44,5 +88,40 @@ func main() {
 			log.Fatal(err)
 		}
 		log.Println(resp)
+		if resp.Route != nil {
+			log.Printf("ride=%s route %.2fkm eta=%ds rerouted=%v polyline=%s\n", resp.RideId, resp.Route.Distance, resp.Route.EtaSeconds, resp.Rerouted, resp.Route.Polyline)
+		}
+		last = resp
 	}
+
+	if last != nil && last.State == ridev1.RideState_COMPLETED && last.Fare != nil {
+		log.Printf("ride=%s fare=%.2f %s\n", last.RideId, float64(last.Fare.Total)/100, last.Fare.Currency)
+	}
+}
+
+// login registers the rider account on first use and returns an access token for it
+func login(username, password string) (string, error) {
+	conn, err := grpc.Dial(AUTH_SERVICE_ADDR, grpc.WithInsecure())
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	auth := authv1.NewAuthClient(conn)
+
+	_, err = auth.Register(context.Background(), &authv1.RegisterRequest{
+		Username: username,
+		Password: password,
+		Role:     authv1.Role_RIDER,
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return "", err
+	}
+
+	pair, err := auth.Login(context.Background(), &authv1.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		return "", err
+	}
+
+	return pair.AccessToken, nil
 }
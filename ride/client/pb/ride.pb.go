@@ -1,250 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.19.4
-// source: ride.proto
-
-package pb
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	reflect "reflect"
-	sync "sync"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-type StartRideRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Username      string            `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	StartLocation *LocationMetadata `protobuf:"bytes,2,opt,name=startLocation,proto3" json:"startLocation,omitempty"`
-	EndLocation   *LocationMetadata `protobuf:"bytes,3,opt,name=endLocation,proto3" json:"endLocation,omitempty"`
-}
-
-func (x *StartRideRequest) Reset() {
-	*x = StartRideRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_ride_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *StartRideRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*StartRideRequest) ProtoMessage() {}
-
-func (x *StartRideRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ride_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use StartRideRequest.ProtoReflect.Descriptor instead.
-func (*StartRideRequest) Descriptor() ([]byte, []int) {
-	return file_ride_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *StartRideRequest) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
-}
-
-func (x *StartRideRequest) GetStartLocation() *LocationMetadata {
-	if x != nil {
-		return x.StartLocation
-	}
-	return nil
-}
-
-func (x *StartRideRequest) GetEndLocation() *LocationMetadata {
-	if x != nil {
-		return x.EndLocation
-	}
-	return nil
-}
-
-type StartRideResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Matched  bool            `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`
-	Location *DriverLocation `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-}
-
-func (x *StartRideResponse) Reset() {
-	*x = StartRideResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_ride_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *StartRideResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*StartRideResponse) ProtoMessage() {}
-
-func (x *StartRideResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ride_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use StartRideResponse.ProtoReflect.Descriptor instead.
-func (*StartRideResponse) Descriptor() ([]byte, []int) {
-	return file_ride_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *StartRideResponse) GetMatched() bool {
-	if x != nil {
-		return x.Matched
-	}
-	return false
-}
-
-func (x *StartRideResponse) GetLocation() *DriverLocation {
-	if x != nil {
-		return x.Location
-	}
-	return nil
-}
-
-var File_ride_proto protoreflect.FileDescriptor
-
-var file_ride_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x64, 0x72,
-	0x69, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x10, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x0d, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x65, 0x6e,
-	0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5a, 0x0a, 0x11, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x44, 0x72, 0x69,
-	0x76, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x38, 0x0a, 0x04, 0x52, 0x69, 0x64, 0x65, 0x12, 0x30, 0x0a,
-	0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x11, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x69,
-	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42,
-	0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_ride_proto_rawDescOnce sync.Once
-	file_ride_proto_rawDescData = file_ride_proto_rawDesc
-)
-
-func file_ride_proto_rawDescGZIP() []byte {
-	file_ride_proto_rawDescOnce.Do(func() {
-		file_ride_proto_rawDescData = protoimpl.X.CompressGZIP(file_ride_proto_rawDescData)
-	})
-	return file_ride_proto_rawDescData
-}
-
-var file_ride_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_ride_proto_goTypes = []interface{}{
-	(*StartRideRequest)(nil),  // 0: StartRideRequest
-	(*StartRideResponse)(nil), // 1: StartRideResponse
-	(*LocationMetadata)(nil),  // 2: LocationMetadata
-	(*DriverLocation)(nil),    // 3: DriverLocation
-}
-var file_ride_proto_depIdxs = []int32{
-	2, // 0: StartRideRequest.startLocation:type_name -> LocationMetadata
-	2, // 1: StartRideRequest.endLocation:type_name -> LocationMetadata
-	3, // 2: StartRideResponse.location:type_name -> DriverLocation
-	0, // 3: Ride.Start:input_type -> StartRideRequest
-	1, // 4: Ride.Start:output_type -> StartRideResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
-}
-
-func init() { file_ride_proto_init() }
-func file_ride_proto_init() {
-	if File_ride_proto != nil {
-		return
-	}
-	file_driver_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_ride_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartRideRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_ride_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartRideResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_ride_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_ride_proto_goTypes,
-		DependencyIndexes: file_ride_proto_depIdxs,
-		MessageInfos:      file_ride_proto_msgTypes,
-	}.Build()
-	File_ride_proto = out.File
-	file_ride_proto_rawDesc = nil
-	file_ride_proto_goTypes = nil
-	file_ride_proto_depIdxs = nil
-}
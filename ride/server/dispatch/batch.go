@@ -0,0 +1,177 @@
+package dispatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+	"go.uber.org/zap"
+)
+
+// NO_PAIR is the cost of a rider and a driver that is not one of their candidates,
+// high enough that the assignment only pairs them when nothing else is left
+const NO_PAIR = 1e9
+
+// Request is a ride waiting for a driver
+type Request struct {
+	RideID string
+	Pickup rank.Point
+	// km around the pickup
+	Radius float64
+	// drivers that must not be assigned, e.g. the ones that already declined the ride
+	Exclude []string
+}
+
+// Assignment is the outcome of a batch for a ride
+type Assignment struct {
+	// the drivers found around the pickup and their scores, the best first
+	Scores []rank.Score
+	// the driver assigned to the ride, empty when the other rides of the batch got all the candidates
+	Driver string
+}
+
+// Finder returns the free drivers that can be offered the ride
+type Finder func(ctx context.Context, req Request) ([]rank.Candidate, error)
+
+type waiter struct {
+	ctx    context.Context
+	req    Request
+	result chan batchResult
+}
+
+type batchResult struct {
+	assignment Assignment
+	err        error
+}
+
+// Batcher collects the rides over a window and assigns the drivers to all of them at once,
+// so that the sum of the scores of the pairs is the highest instead of every ride taking its best driver in turn
+type Batcher struct {
+	log    *zap.Logger
+	window time.Duration
+	ranker rank.Ranker
+	find   Finder
+
+	mu      sync.Mutex
+	pending []*waiter
+}
+
+func NewBatcher(log *zap.Logger, window time.Duration, ranker rank.Ranker, find Finder) *Batcher {
+	return &Batcher{
+		log:    log,
+		window: window,
+		ranker: ranker,
+		find:   find,
+	}
+}
+
+// Submit adds the ride to the current batch and waits for its assignment, the first ride of a batch opens its window
+func (b *Batcher) Submit(ctx context.Context, req Request) (Assignment, error) {
+	w := &waiter{ctx: ctx, req: req, result: make(chan batchResult, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, w)
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case result := <-w.result:
+		return result.assignment, result.err
+	case <-ctx.Done():
+		return Assignment{}, ctx.Err()
+	}
+}
+
+// flush closes the current batch and assigns its rides
+func (b *Batcher) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	// the rides given up meanwhile don't take a driver
+	waiting := batch[:0]
+	for _, w := range batch {
+		if w.ctx.Err() == nil {
+			waiting = append(waiting, w)
+		}
+	}
+	if len(waiting) == 0 {
+		return
+	}
+
+	scores := make([][]rank.Score, len(waiting))
+	errs := make([]error, len(waiting))
+	var wg sync.WaitGroup
+	for idx, w := range waiting {
+		wg.Add(1)
+		go func(idx int, w *waiter) {
+			defer wg.Done()
+
+			candidates, err := b.find(w.ctx, w.req)
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			scores[idx] = b.ranker.Rank(w.req.Pickup, candidates)
+		}(idx, w)
+	}
+	wg.Wait()
+
+	drivers := assign(scores)
+
+	matched := 0
+	for idx, w := range waiting {
+		if errs[idx] != nil {
+			w.result <- batchResult{err: errs[idx]}
+			continue
+		}
+		if drivers[idx] != "" {
+			matched++
+		}
+		w.result <- batchResult{assignment: Assignment{Scores: scores[idx], Driver: drivers[idx]}}
+	}
+
+	b.log.Info("Dispatched batch", zap.Int("rides", len(waiting)), zap.Int("matched", matched))
+}
+
+// assign pairs the rides with the drivers so that the sum of the scores of the pairs is the highest,
+// it returns the driver of every ride, empty when the ride got none
+func assign(scores [][]rank.Score) []string {
+	columns := make(map[string]int)
+	var drivers []string
+	for _, ride := range scores {
+		for _, score := range ride {
+			if _, ok := columns[score.Driver]; !ok {
+				columns[score.Driver] = len(drivers)
+				drivers = append(drivers, score.Driver)
+			}
+		}
+	}
+
+	assigned := make([]string, len(scores))
+	if len(drivers) == 0 {
+		return assigned
+	}
+
+	costs := make([][]float64, len(scores))
+	for idx, ride := range scores {
+		costs[idx] = make([]float64, len(drivers))
+		for column := range costs[idx] {
+			costs[idx][column] = NO_PAIR
+		}
+		for _, score := range ride {
+			costs[idx][columns[score.Driver]] = -score.Total
+		}
+	}
+
+	for idx, column := range Assign(costs) {
+		if column >= 0 && costs[idx][column] < NO_PAIR {
+			assigned[idx] = drivers[column]
+		}
+	}
+	return assigned
+}
@@ -0,0 +1,76 @@
+package dispatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+	"go.uber.org/zap"
+)
+
+// distanceRanker ranks the drivers on their distance only
+func distanceRanker() rank.Ranker {
+	cfg := config.Defaults(8082)
+	cfg.Ride.Ranking = config.RankWeights{Distance: 1}
+	return rank.NewRanker(&cfg)
+}
+
+func TestBatcherAssignsTheBatchTogether(t *testing.T) {
+	// taking its closest driver, ride-a would leave ride-b without one
+	candidates := map[string][]rank.Candidate{
+		"ride-a": {{Name: "driver-1", Distance: 1}, {Name: "driver-2", Distance: 2}},
+		"ride-b": {{Name: "driver-1", Distance: 1.5}},
+		"ride-c": {{Name: "driver-2", Distance: 1}},
+	}
+	find := func(ctx context.Context, req Request) ([]rank.Candidate, error) {
+		return candidates[req.RideID], nil
+	}
+	batcher := NewBatcher(zap.NewNop(), 50*time.Millisecond, distanceRanker(), find)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// ride-c gives up before the batch is assigned
+	cancel()
+
+	results := make(map[string]chan Assignment)
+	for _, ride := range []string{"ride-a", "ride-b", "ride-c"} {
+		rideCtx := context.Background()
+		if ride == "ride-c" {
+			rideCtx = ctx
+		}
+		results[ride] = make(chan Assignment, 1)
+		go func(ctx context.Context, ride string) {
+			assignment, _ := batcher.Submit(ctx, Request{RideID: ride})
+			results[ride] <- assignment
+		}(rideCtx, ride)
+	}
+
+	want := map[string]string{"ride-a": "driver-2", "ride-b": "driver-1", "ride-c": ""}
+	for ride, driver := range want {
+		select {
+		case assignment := <-results[ride]:
+			if assignment.Driver != driver {
+				t.Fatalf("%s got %q, want %q", ride, assignment.Driver, driver)
+			}
+			if driver != "" && len(assignment.Scores) != len(candidates[ride]) {
+				t.Fatalf("%s got the scores %v, want one per candidate", ride, assignment.Scores)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s got no assignment", ride)
+		}
+	}
+}
+
+func TestAssignLeavesOutTheRidesWithoutDriver(t *testing.T) {
+	scores := [][]rank.Score{
+		{{Driver: "driver-1", Total: 0.9}},
+		{{Driver: "driver-1", Total: 0.5}},
+		nil,
+	}
+
+	got := assign(scores)
+	if got[0] != "driver-1" || got[1] != "" || got[2] != "" {
+		t.Fatalf("assign() = %q, want driver-1 to the first ride only", got)
+	}
+}
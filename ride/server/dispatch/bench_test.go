@@ -0,0 +1,133 @@
+package dispatch
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+)
+
+const (
+	// the simulated city is a square of CITY_KM
+	CITY_KM          = 10
+	SEARCH_RADIUS_KM = 3
+	// as many candidates as the ride service ranks
+	MAX_CANDIDATES = 20
+)
+
+type peak struct {
+	rides   []rank.Point
+	drivers []rank.Candidate
+	// the scores of the candidates of every ride, the best first
+	scores [][]rank.Score
+}
+
+// newPeak places the rides and the free drivers at random in the city, every ride ranks the closest drivers around it
+func newPeak(random *rand.Rand, ranker rank.Ranker, rides, drivers int) *peak {
+	point := func() rank.Point {
+		return rank.Point{Latitude: random.Float64() * CITY_KM, Longitude: random.Float64() * CITY_KM}
+	}
+
+	p := &peak{rides: make([]rank.Point, rides), drivers: make([]rank.Candidate, drivers), scores: make([][]rank.Score, rides)}
+	for idx := range p.drivers {
+		p.drivers[idx] = rank.Candidate{Name: fmt.Sprintf("driver-%d", idx), Point: point()}
+	}
+	for idx := range p.rides {
+		p.rides[idx] = point()
+
+		var candidates []rank.Candidate
+		for _, driver := range p.drivers {
+			// a flat city, the coordinates are in km
+			driver.Distance = math.Hypot(driver.Latitude-p.rides[idx].Latitude, driver.Longitude-p.rides[idx].Longitude)
+			if driver.Distance <= SEARCH_RADIUS_KM {
+				candidates = append(candidates, driver)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Distance < candidates[j].Distance })
+		if len(candidates) > MAX_CANDIDATES {
+			candidates = candidates[:MAX_CANDIDATES]
+		}
+		p.scores[idx] = ranker.Rank(p.rides[idx], candidates)
+	}
+
+	return p
+}
+
+// greedy is the matching of every ride on its own: the rides, in the order they came, take their best driver left
+func greedy(scores [][]rank.Score) []string {
+	taken := make(map[string]bool)
+	assigned := make([]string, len(scores))
+	for idx, ride := range scores {
+		for _, score := range ride {
+			if !taken[score.Driver] {
+				taken[score.Driver] = true
+				assigned[idx] = score.Driver
+				break
+			}
+		}
+	}
+	return assigned
+}
+
+// pickupKm is the total distance the drivers drive to their pickups
+func (p *peak) pickupKm(assigned []string) (float64, int) {
+	drivers := make(map[string]rank.Point, len(p.drivers))
+	for _, driver := range p.drivers {
+		drivers[driver.Name] = driver.Point
+	}
+
+	total, matched := 0.0, 0
+	for idx, name := range assigned {
+		if name == "" {
+			continue
+		}
+		driver := drivers[name]
+		total += math.Hypot(driver.Latitude-p.rides[idx].Latitude, driver.Longitude-p.rides[idx].Longitude)
+		matched++
+	}
+	return total, matched
+}
+
+// BenchmarkDispatch compares the greedy matching with the batched one on simulated peaks,
+// it reports the average pickup distance and the share of the rides that got a driver
+func BenchmarkDispatch(b *testing.B) {
+	cfg := config.Defaults(8082)
+	ranker := rank.NewRanker(&cfg)
+
+	modes := []struct {
+		name   string
+		assign func([][]rank.Score) []string
+	}{
+		{"greedy", greedy},
+		{"batch", assign},
+	}
+
+	for _, size := range [][2]int{{20, 40}, {50, 50}, {100, 80}} {
+		for _, mode := range modes {
+			b.Run(fmt.Sprintf("%s/rides=%d/drivers=%d", mode.name, size[0], size[1]), func(b *testing.B) {
+				random := rand.New(rand.NewSource(1))
+				peaks := make([]*peak, 20)
+				for idx := range peaks {
+					peaks[idx] = newPeak(random, ranker, size[0], size[1])
+				}
+
+				var pickup float64
+				var matched int
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					p := peaks[i%len(peaks)]
+					km, count := p.pickupKm(mode.assign(p.scores))
+					pickup += km
+					matched += count
+				}
+
+				b.ReportMetric(pickup/float64(matched), "pickup_km/ride")
+				b.ReportMetric(float64(matched)/float64(b.N*size[0]), "matched/ride")
+			})
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package dispatch
+
+import "math"
+
+// Assign solves the assignment problem with the Hungarian algorithm: every row gets a distinct column
+// so that the sum of their costs is the lowest. It returns the column of every row, -1 for the rows left out
+// when there are more rows than columns. It runs in O(rows² * columns).
+func Assign(costs [][]float64) []int {
+	rows := len(costs)
+	if rows == 0 {
+		return nil
+	}
+	columns := len(costs[0])
+	if rows > columns {
+		return assignTransposed(costs)
+	}
+
+	// the rows and the columns are counted from 1, column 0 holds the row being added
+	u := make([]float64, rows+1)
+	v := make([]float64, columns+1)
+	// p is the row of every column, way the previous column on the augmenting path
+	p := make([]int, columns+1)
+	way := make([]int, columns+1)
+
+	for i := 1; i <= rows; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, columns+1)
+		used := make([]bool, columns+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= columns; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := costs[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= columns; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+
+		// flip the augmenting path
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assigned := make([]int, rows)
+	for j := 1; j <= columns; j++ {
+		if p[j] != 0 {
+			assigned[p[j]-1] = j - 1
+		}
+	}
+	return assigned
+}
+
+// assignTransposed assigns the columns to the rows instead, when there are more rows than columns
+func assignTransposed(costs [][]float64) []int {
+	transposed := make([][]float64, len(costs[0]))
+	for j := range transposed {
+		transposed[j] = make([]float64, len(costs))
+		for i := range costs {
+			transposed[j][i] = costs[i][j]
+		}
+	}
+
+	assigned := make([]int, len(costs))
+	for i := range assigned {
+		assigned[i] = -1
+	}
+	for j, i := range Assign(transposed) {
+		assigned[i] = j
+	}
+	return assigned
+}
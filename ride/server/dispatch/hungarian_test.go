@@ -0,0 +1,83 @@
+package dispatch
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForce returns the lowest total cost of the assignments that pair as many rows as possible, trying them all
+func bruteForce(costs [][]float64) float64 {
+	rows, columns := len(costs), len(costs[0])
+	pairs := rows
+	if columns < rows {
+		pairs = columns
+	}
+	used := make([]bool, columns)
+
+	var best func(row, paired int) float64
+	best = func(row, paired int) float64 {
+		if row == rows {
+			if paired < pairs {
+				return math.Inf(1)
+			}
+			return 0
+		}
+		// a row may be left out when there are more rows than columns
+		lowest := best(row+1, paired)
+		for column := 0; column < columns; column++ {
+			if used[column] {
+				continue
+			}
+			used[column] = true
+			lowest = math.Min(lowest, costs[row][column]+best(row+1, paired+1))
+			used[column] = false
+		}
+		return lowest
+	}
+
+	return best(0, 0)
+}
+
+func TestAssignIsOptimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for _, size := range [][2]int{{1, 1}, {3, 3}, {4, 6}, {6, 4}, {6, 6}, {2, 7}} {
+		for round := 0; round < 20; round++ {
+			costs := make([][]float64, size[0])
+			for i := range costs {
+				costs[i] = make([]float64, size[1])
+				for j := range costs[i] {
+					costs[i][j] = math.Round(random.Float64()*100) - 50
+				}
+			}
+
+			assigned := Assign(costs)
+
+			total, taken, left := 0.0, make(map[int]bool), 0
+			for row, column := range assigned {
+				if column < 0 {
+					left++
+					continue
+				}
+				if taken[column] {
+					t.Fatalf("%v: column %d assigned twice in %v", size, column, assigned)
+				}
+				taken[column] = true
+				total += costs[row][column]
+			}
+			if want := size[0] - size[1]; left != want && !(want < 0 && left == 0) {
+				t.Fatalf("%v: %d rows left out, want %d", size, left, want)
+			}
+			if want := bruteForce(costs); math.Abs(total-want) > 1e-9 {
+				t.Fatalf("%v: total cost %v, want %v", size, total, want)
+			}
+		}
+	}
+}
+
+func TestAssignEmpty(t *testing.T) {
+	if assigned := Assign(nil); len(assigned) != 0 {
+		t.Fatalf("Assign(nil) = %v", assigned)
+	}
+}
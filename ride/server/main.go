@@ -1,20 +1,44 @@
 package main
 
 import (
+	"os"
+
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/rdb"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/pricing"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
 	"github.com/alexcogojocaru/cloud-computing-project/ride/service"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/zap"
 )
 
+func NewConfig() (*config.Config, error) {
+	return config.Load(os.Args[1:], config.Defaults(8082), config.RequireRedis, config.RequireMessaging, config.RequireRideStore, config.RequireRanking, config.RequireMatching, config.RequireDispatch, config.RequirePricing, config.RequireAuth)
+}
+
 func main() {
 	fx.New(
 		fx.WithLogger(func(log *zap.Logger) fxevent.Logger {
 			return &fxevent.ZapLogger{Logger: log}
 		}),
 		fx.Provide(
+			NewConfig,
 			NewRideService,
 			service.NewRideGrpcService,
+			rdb.New,
+			events.Topology,
+			messaging.NewBroker,
+			messaging.AsPublisher,
+			service.NewRideStore,
+			rank.NewRanker,
+			route.NewRouter,
+			pricing.NewPricer,
+			token.NewManagerFromConfig,
 			zap.NewExample,
 		), fx.Invoke(
 			func(*RideService) {},
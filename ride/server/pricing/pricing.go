@@ -0,0 +1,112 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
+)
+
+// MINOR_UNITS is how many minor units make a unit of the currency, e.g. 100 bani in a leu
+const MINOR_UNITS = 100
+
+var ErrNoRateCard = errors.New("No rate card for the ride")
+
+// VEHICLES are the vehicle classes in the order they are listed to the riders
+var VEHICLES = []string{config.VEHICLE_STANDARD, config.VEHICLE_COMFORT, config.VEHICLE_XL}
+
+// Fare is the price of a ride, the amounts are in the minor unit of the currency
+type Fare struct {
+	Currency string
+	City     string
+	Vehicle  string
+
+	BaseFare     int64
+	DistanceFare int64
+	TimeFare     int64
+	// raises the fare of a short ride to the minimum one
+	MinimumFareSurcharge int64
+	BookingFee           int64
+	Total                int64
+}
+
+// Pricer prices the rides with the rate cards of the city they start in
+type Pricer struct {
+	currency string
+	cities   []config.City
+}
+
+func NewPricer(cfg *config.Config) *Pricer {
+	return &Pricer{
+		currency: cfg.Ride.Pricing.Currency,
+		cities:   cfg.Ride.Pricing.Cities,
+	}
+}
+
+// City returns the city the point is in, the one with the closest center when their areas overlap
+func (p *Pricer) City(point route.Point) (config.City, bool) {
+	var found config.City
+	closest := math.Inf(1)
+	for _, city := range p.cities {
+		distance := route.Distance(point, route.Point{Latitude: city.Latitude, Longitude: city.Longitude})
+		if distance <= city.Radius && distance < closest {
+			found, closest = city, distance
+		}
+	}
+	return found, !math.IsInf(closest, 1)
+}
+
+// Vehicles returns the vehicle classes priced in the city
+func Vehicles(city config.City) []string {
+	var vehicles []string
+	for _, vehicle := range VEHICLES {
+		if _, ok := city.Rates[vehicle]; ok {
+			vehicles = append(vehicles, vehicle)
+		}
+	}
+	return vehicles
+}
+
+// Fare prices a ride starting at the pickup in the vehicle class, km long and lasting duration
+func (p *Pricer) Fare(pickup route.Point, vehicle string, km float64, duration time.Duration) (Fare, error) {
+	city, ok := p.City(pickup)
+	if !ok {
+		return Fare{}, ErrNoRateCard
+	}
+	card, ok := city.Rates[vehicle]
+	if !ok {
+		return Fare{}, ErrNoRateCard
+	}
+
+	fare := Compute(card, km, duration)
+	fare.Currency = p.currency
+	fare.City = city.Name
+	fare.Vehicle = vehicle
+	return fare, nil
+}
+
+// Compute applies the rate card: the base fare, the km and the minutes make the fare of the ride,
+// it is raised to the minimum fare and the booking fee is added on top
+func Compute(card config.RateCard, km float64, duration time.Duration) Fare {
+	fare := Fare{
+		BaseFare:     minor(card.BaseFare),
+		DistanceFare: minor(card.PerKm * km),
+		TimeFare:     minor(card.PerMinute * duration.Minutes()),
+		BookingFee:   minor(card.BookingFee),
+	}
+
+	ride := fare.BaseFare + fare.DistanceFare + fare.TimeFare
+	if minimum := minor(card.MinimumFare); ride < minimum {
+		fare.MinimumFareSurcharge = minimum - ride
+	}
+	fare.Total = ride + fare.MinimumFareSurcharge + fare.BookingFee
+
+	return fare
+}
+
+// minor rounds an amount to the minor unit of the currency
+func minor(amount float64) int64 {
+	return int64(math.Round(amount * MINOR_UNITS))
+}
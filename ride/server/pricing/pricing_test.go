@@ -0,0 +1,87 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
+)
+
+var card = config.RateCard{BaseFare: 4, PerKm: 2.2, PerMinute: 0.4, MinimumFare: 10, BookingFee: 1}
+
+func TestCompute(t *testing.T) {
+	fare := Compute(card, 5.5, 12*time.Minute+30*time.Second)
+
+	want := Fare{
+		BaseFare:     400,
+		DistanceFare: 1210,
+		TimeFare:     500,
+		BookingFee:   100,
+		Total:        2210,
+	}
+	if fare != want {
+		t.Fatalf("Compute() = %+v, want %+v", fare, want)
+	}
+}
+
+func TestComputeMinimumFare(t *testing.T) {
+	fare := Compute(card, 1, 2*time.Minute)
+
+	// 4 + 2.2 + 0.8 is raised to 10, the booking fee is on top
+	if fare.MinimumFareSurcharge != 300 || fare.Total != 1100 {
+		t.Fatalf("Compute() = %+v, want a 3 lei surcharge and 11 lei in total", fare)
+	}
+}
+
+func TestFareUsesTheRateCardOfTheCity(t *testing.T) {
+	cfg := config.Defaults(8082)
+	cfg.Ride.Pricing.Cities = append(cfg.Ride.Pricing.Cities, config.City{
+		Name:      "cluj",
+		Latitude:  46.77,
+		Longitude: 23.6,
+		Radius:    12,
+		Rates:     map[string]config.RateCard{config.VEHICLE_STANDARD: {BaseFare: 10}},
+	})
+	pricer := NewPricer(&cfg)
+
+	iasi := route.Point{Latitude: 47.16, Longitude: 27.59}
+	cluj := route.Point{Latitude: 46.77, Longitude: 23.59}
+
+	fare, err := pricer.Fare(cluj, config.VEHICLE_STANDARD, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fare.City != "cluj" || fare.Currency != "RON" || fare.Total != 1000 {
+		t.Fatalf("fare = %+v, want the cluj rate card", fare)
+	}
+
+	standard, err := pricer.Fare(iasi, config.VEHICLE_STANDARD, 5, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xl, err := pricer.Fare(iasi, config.VEHICLE_XL, 5, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if standard.City != "iasi" || xl.Total <= standard.Total {
+		t.Fatalf("standard = %+v, xl = %+v, want the xl to cost more in iasi", standard, xl)
+	}
+
+	if _, err := pricer.Fare(cluj, config.VEHICLE_XL, 5, 0); !errors.Is(err, ErrNoRateCard) {
+		t.Fatalf("err = %v, want ErrNoRateCard for a class cluj doesn't price", err)
+	}
+	if _, err := pricer.Fare(route.Point{Latitude: 44.43, Longitude: 26.1}, config.VEHICLE_STANDARD, 5, 0); !errors.Is(err, ErrNoRateCard) {
+		t.Fatalf("err = %v, want ErrNoRateCard outside of the cities", err)
+	}
+}
+
+func TestVehicles(t *testing.T) {
+	city := config.City{Rates: map[string]config.RateCard{config.VEHICLE_XL: {}, config.VEHICLE_STANDARD: {}}}
+
+	vehicles := Vehicles(city)
+	if len(vehicles) != 2 || vehicles[0] != config.VEHICLE_STANDARD || vehicles[1] != config.VEHICLE_XL {
+		t.Fatalf("Vehicles() = %v, want standard then xl", vehicles)
+	}
+}
@@ -1,177 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.19.4
-// source: driver.proto
-
-package pb
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
-
-// DriverClient is the client API for Driver service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type DriverClient interface {
-	GetClosest(ctx context.Context, in *LocationMetadata, opts ...grpc.CallOption) (*DriverLocationList, error)
-	GetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*DriverStatusMetadata, error)
-	SetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*Empty, error)
-}
-
-type driverClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewDriverClient(cc grpc.ClientConnInterface) DriverClient {
-	return &driverClient{cc}
-}
-
-func (c *driverClient) GetClosest(ctx context.Context, in *LocationMetadata, opts ...grpc.CallOption) (*DriverLocationList, error) {
-	out := new(DriverLocationList)
-	err := c.cc.Invoke(ctx, "/Driver/GetClosest", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *driverClient) GetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*DriverStatusMetadata, error) {
-	out := new(DriverStatusMetadata)
-	err := c.cc.Invoke(ctx, "/Driver/GetStatus", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *driverClient) SetStatus(ctx context.Context, in *DriverStatusMetadata, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/Driver/SetStatus", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// DriverServer is the server API for Driver service.
-// All implementations must embed UnimplementedDriverServer
-// for forward compatibility
-type DriverServer interface {
-	GetClosest(context.Context, *LocationMetadata) (*DriverLocationList, error)
-	GetStatus(context.Context, *DriverStatusMetadata) (*DriverStatusMetadata, error)
-	SetStatus(context.Context, *DriverStatusMetadata) (*Empty, error)
-	mustEmbedUnimplementedDriverServer()
-}
-
-// UnimplementedDriverServer must be embedded to have forward compatible implementations.
-type UnimplementedDriverServer struct {
-}
-
-func (UnimplementedDriverServer) GetClosest(context.Context, *LocationMetadata) (*DriverLocationList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetClosest not implemented")
-}
-func (UnimplementedDriverServer) GetStatus(context.Context, *DriverStatusMetadata) (*DriverStatusMetadata, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
-}
-func (UnimplementedDriverServer) SetStatus(context.Context, *DriverStatusMetadata) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetStatus not implemented")
-}
-func (UnimplementedDriverServer) mustEmbedUnimplementedDriverServer() {}
-
-// UnsafeDriverServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DriverServer will
-// result in compilation errors.
-type UnsafeDriverServer interface {
-	mustEmbedUnimplementedDriverServer()
-}
-
-func RegisterDriverServer(s grpc.ServiceRegistrar, srv DriverServer) {
-	s.RegisterService(&Driver_ServiceDesc, srv)
-}
-
-func _Driver_GetClosest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LocationMetadata)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DriverServer).GetClosest(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Driver/GetClosest",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DriverServer).GetClosest(ctx, req.(*LocationMetadata))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Driver_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DriverStatusMetadata)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DriverServer).GetStatus(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Driver/GetStatus",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DriverServer).GetStatus(ctx, req.(*DriverStatusMetadata))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Driver_SetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DriverStatusMetadata)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DriverServer).SetStatus(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/Driver/SetStatus",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DriverServer).SetStatus(ctx, req.(*DriverStatusMetadata))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// Driver_ServiceDesc is the grpc.ServiceDesc for Driver service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Driver_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "Driver",
-	HandlerType: (*DriverServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetClosest",
-			Handler:    _Driver_GetClosest_Handler,
-		},
-		{
-			MethodName: "GetStatus",
-			Handler:    _Driver_GetStatus_Handler,
-		},
-		{
-			MethodName: "SetStatus",
-			Handler:    _Driver_SetStatus_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "driver.proto",
-}
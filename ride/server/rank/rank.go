@@ -0,0 +1,141 @@
+package rank
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/compass"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+)
+
+// the factors a driver is scored on
+const (
+	FACTOR_DISTANCE   = "distance"
+	FACTOR_RATING     = "rating"
+	FACTOR_ACCEPTANCE = "acceptance"
+	FACTOR_IDLE       = "idle"
+	FACTOR_HEADING    = "heading"
+)
+
+const (
+	// a driver with few ratings or offers is scored as if they also had PRIOR_WEIGHT average ones
+	PRIOR_WEIGHT     = 5
+	PRIOR_RATING     = 4.5
+	PRIOR_ACCEPTANCE = 0.8
+	// drivers FREE for this long or more get the whole idle factor
+	IDLE_CAP = 30 * time.Minute
+	// closer than this, the driver is at the pickup whatever their heading
+	AT_PICKUP_KM = 0.05
+)
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Candidate is a driver the ride can be offered to, with what is known about them
+type Candidate struct {
+	Name string
+	Point
+	// km to the pickup
+	Distance float64
+	// degrees clockwise from north, nil when their device doesn't tell
+	Heading *float64
+	// the sum of the driver's ratings, from 1 to 5 stars, and how many there are
+	RatingSum   int64
+	RatingCount int64
+	// the offers the driver accepted out of all the ones they were made
+	Accepted int64
+	Offered  int64
+	// how long the driver has been FREE, 0 when unknown
+	Idle time.Duration
+}
+
+// Score is a candidate's score with the weighted value of every factor, they add up to the total
+type Score struct {
+	Driver  string
+	Total   float64
+	Factors map[string]float64
+}
+
+// Ranker orders the candidates of a ride, the best one first
+type Ranker interface {
+	Rank(pickup Point, candidates []Candidate) []Score
+}
+
+// WeightedRanker scores every factor between 0 and 1 and sums them up with the configured weights
+type WeightedRanker struct {
+	weights config.RankWeights
+}
+
+func NewWeightedRanker(cfg *config.Config) *WeightedRanker {
+	return &WeightedRanker{weights: cfg.Ride.Ranking}
+}
+
+// NewRanker is the default Ranker
+func NewRanker(cfg *config.Config) Ranker {
+	return NewWeightedRanker(cfg)
+}
+
+func (r *WeightedRanker) Rank(pickup Point, candidates []Candidate) []Score {
+	scores := make([]Score, len(candidates))
+	distances := make(map[string]float64, len(candidates))
+	for idx, candidate := range candidates {
+		factors := map[string]float64{
+			FACTOR_DISTANCE:   r.weights.Distance * distanceFactor(candidate),
+			FACTOR_RATING:     r.weights.Rating * ratingFactor(candidate),
+			FACTOR_ACCEPTANCE: r.weights.Acceptance * acceptanceFactor(candidate),
+			FACTOR_IDLE:       r.weights.Idle * idleFactor(candidate),
+			FACTOR_HEADING:    r.weights.Heading * headingFactor(pickup, candidate),
+		}
+
+		total := 0.0
+		for _, value := range factors {
+			total += value
+		}
+		scores[idx] = Score{Driver: candidate.Name, Total: total, Factors: factors}
+		distances[candidate.Name] = candidate.Distance
+	}
+
+	// the closest driver wins a tie
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Total != scores[j].Total {
+			return scores[i].Total > scores[j].Total
+		}
+		return distances[scores[i].Driver] < distances[scores[j].Driver]
+	})
+
+	return scores
+}
+
+func distanceFactor(candidate Candidate) float64 {
+	return 1 / (1 + candidate.Distance)
+}
+
+func ratingFactor(candidate Candidate) float64 {
+	rating := (float64(candidate.RatingSum) + PRIOR_RATING*PRIOR_WEIGHT) / float64(candidate.RatingCount+PRIOR_WEIGHT)
+	return (rating - 1) / 4
+}
+
+func acceptanceFactor(candidate Candidate) float64 {
+	return (float64(candidate.Accepted) + PRIOR_ACCEPTANCE*PRIOR_WEIGHT) / float64(candidate.Offered+PRIOR_WEIGHT)
+}
+
+func idleFactor(candidate Candidate) float64 {
+	return math.Min(float64(candidate.Idle)/float64(IDLE_CAP), 1)
+}
+
+// headingFactor is 1 for a driver heading straight to the pickup, 0 for one heading away from it
+// and 0.5 when the heading is unknown
+func headingFactor(pickup Point, candidate Candidate) float64 {
+	if candidate.Distance < AT_PICKUP_KM {
+		return 1
+	}
+	if candidate.Heading == nil {
+		return 0.5
+	}
+
+	turn := (*candidate.Heading - compass.Bearing(candidate.Latitude, candidate.Longitude, pickup.Latitude, pickup.Longitude)) * math.Pi / 180
+	return (1 + math.Cos(turn)) / 2
+}
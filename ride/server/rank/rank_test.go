@@ -0,0 +1,112 @@
+package rank
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+)
+
+func onlyWeight(set func(*config.RankWeights)) *WeightedRanker {
+	cfg := config.Defaults(8082)
+	cfg.Ride.Ranking = config.RankWeights{}
+	set(&cfg.Ride.Ranking)
+	return NewWeightedRanker(&cfg)
+}
+
+func order(scores []Score) []string {
+	names := make([]string, len(scores))
+	for idx, score := range scores {
+		names[idx] = score.Driver
+	}
+	return names
+}
+
+func TestWeightedRankerFactors(t *testing.T) {
+	pickup := Point{Latitude: 47.16, Longitude: 27.59}
+	north := 0.0
+	south := 180.0
+
+	tests := []struct {
+		name       string
+		ranker     *WeightedRanker
+		candidates []Candidate
+		want       []string
+	}{
+		{
+			"distance",
+			onlyWeight(func(w *config.RankWeights) { w.Distance = 1 }),
+			[]Candidate{{Name: "far", Distance: 3}, {Name: "close", Distance: 1}},
+			[]string{"close", "far"},
+		},
+		{
+			"rating",
+			onlyWeight(func(w *config.RankWeights) { w.Rating = 1 }),
+			[]Candidate{{Name: "low", RatingSum: 20, RatingCount: 10}, {Name: "new"}, {Name: "high", RatingSum: 50, RatingCount: 10}},
+			[]string{"high", "new", "low"},
+		},
+		{
+			"acceptance",
+			onlyWeight(func(w *config.RankWeights) { w.Acceptance = 1 }),
+			[]Candidate{{Name: "declines", Accepted: 1, Offered: 10}, {Name: "accepts", Accepted: 10, Offered: 10}},
+			[]string{"accepts", "declines"},
+		},
+		{
+			"idle",
+			onlyWeight(func(w *config.RankWeights) { w.Idle = 1 }),
+			[]Candidate{{Name: "just freed", Idle: time.Minute}, {Name: "waiting", Idle: time.Hour}},
+			[]string{"waiting", "just freed"},
+		},
+		{
+			// both are south of the pickup
+			"heading",
+			onlyWeight(func(w *config.RankWeights) { w.Heading = 1 }),
+			[]Candidate{
+				{Name: "away", Point: Point{Latitude: 47.15, Longitude: 27.59}, Distance: 1, Heading: &south},
+				{Name: "unknown", Point: Point{Latitude: 47.15, Longitude: 27.59}, Distance: 1},
+				{Name: "towards", Point: Point{Latitude: 47.15, Longitude: 27.59}, Distance: 1, Heading: &north},
+			},
+			[]string{"towards", "unknown", "away"},
+		},
+		{
+			"tie",
+			onlyWeight(func(w *config.RankWeights) { w.Rating = 1 }),
+			[]Candidate{{Name: "far", Distance: 2}, {Name: "close", Distance: 1}},
+			[]string{"close", "far"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := order(tt.ranker.Rank(pickup, tt.candidates))
+			for idx := range tt.want {
+				if got[idx] != tt.want[idx] {
+					t.Fatalf("Rank() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedRankerBreakdown(t *testing.T) {
+	cfg := config.Defaults(8082)
+	ranker := NewWeightedRanker(&cfg)
+
+	scores := ranker.Rank(Point{}, []Candidate{{Name: "driver-1", Distance: 1, Idle: IDLE_CAP}})
+
+	score := scores[0]
+	sum := 0.0
+	for _, value := range score.Factors {
+		sum += value
+	}
+	if math.Abs(sum-score.Total) > 1e-9 {
+		t.Fatalf("the factors add up to %v, want the total %v", sum, score.Total)
+	}
+	if score.Factors[FACTOR_DISTANCE] != cfg.Ride.Ranking.Distance/2 {
+		t.Fatalf("distance factor = %v, want %v", score.Factors[FACTOR_DISTANCE], cfg.Ride.Ranking.Distance/2)
+	}
+	if score.Factors[FACTOR_IDLE] != cfg.Ride.Ranking.Idle {
+		t.Fatalf("idle factor = %v, want %v", score.Factors[FACTOR_IDLE], cfg.Ride.Ranking.Idle)
+	}
+}
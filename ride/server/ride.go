@@ -11,11 +11,6 @@ type RideService struct {
 	log *zap.Logger
 }
 
-const (
-	PUBSUB_SUBSCRIPTION = "rider-streaming-sub"
-	GCP_PROJECT         = "cloudcomputing-386413"
-)
-
 func NewRideService(lc fx.Lifecycle, log *zap.Logger) *RideService {
 	rs := &RideService{
 		log: log,
@@ -0,0 +1,395 @@
+package route
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// the side of the cells the nodes are looked up in, in degrees
+	CELL_SIZE = 0.005
+	// how many rings of cells around a point are searched for the closest node, about 1km
+	SNAP_RINGS = 2
+	// the speed between a point and the closest road, e.g. in a parking lot
+	SNAP_SPEED_KMH = 15.0
+	// how many nodes A* settles between two checks of the context
+	CONTEXT_CHECK = 1024
+)
+
+type edge struct {
+	to      int
+	km      float64
+	seconds float64
+}
+
+type cell struct {
+	row    int
+	column int
+}
+
+// Graph is a road network, its nodes are the junctions and the bends of the roads.
+// It is built with AddNode and AddRoad, then it is only read and can route concurrently.
+type Graph struct {
+	nodes []Point
+	edges [][]edge
+	// the fastest road, the A* heuristic drives straight to the end at this speed so that it never overestimates
+	topSpeed float64
+
+	once sync.Once
+	// the nodes the points snap to, by cell
+	cells map[cell][]int
+	// the roads driven the other way, to search from the end of the routes
+	reverse [][]edge
+	// the searchStates of the searches done, they are big enough for the whole graph
+	states sync.Pool
+}
+
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+// AddNode adds a junction or a bend and returns its id
+func (g *Graph) AddNode(p Point) int {
+	g.nodes = append(g.nodes, p)
+	g.edges = append(g.edges, nil)
+	return len(g.nodes) - 1
+}
+
+// AddRoad links two nodes with a road driven at speed km/h, a oneway road is only driven from the first node
+func (g *Graph) AddRoad(from, to int, speed float64, oneway bool) {
+	km := Distance(g.nodes[from], g.nodes[to])
+	seconds := km / speed * 3600
+
+	g.edges[from] = append(g.edges[from], edge{to: to, km: km, seconds: seconds})
+	if !oneway {
+		g.edges[to] = append(g.edges[to], edge{to: from, km: km, seconds: seconds})
+	}
+	g.topSpeed = math.Max(g.topSpeed, speed)
+}
+
+func (g *Graph) Nodes() int {
+	return len(g.nodes)
+}
+
+func (g *Graph) Edges() int {
+	count := 0
+	for _, edges := range g.edges {
+		count += len(edges)
+	}
+	return count
+}
+
+// Route snaps the points to the closest roads and finds the fastest route between them with A*
+func (g *Graph) Route(ctx context.Context, from, to Point) (*Route, error) {
+	g.once.Do(g.index)
+
+	start, ok := g.snap(from)
+	if !ok {
+		return nil, ErrNoRoute
+	}
+	end, ok := g.snap(to)
+	if !ok {
+		return nil, ErrNoRoute
+	}
+
+	path, km, seconds, err := g.search(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.newRoute(from, to, path, km, seconds), nil
+}
+
+// Towards snaps the points to the closest roads and finds the fastest route from every one of them to the same end,
+// with a single search from the end along the reversed roads. It stops once all the points are reached.
+func (g *Graph) Towards(ctx context.Context, from []Point, to Point) ([]*Route, error) {
+	g.once.Do(g.index)
+
+	end, ok := g.snap(to)
+	if !ok {
+		return nil, ErrNoRoute
+	}
+
+	starts := make([]int, len(from))
+	pending := make(map[int]bool, len(from))
+	for idx, p := range from {
+		starts[idx] = -1
+		if node, ok := g.snap(p); ok {
+			starts[idx] = node
+			pending[node] = true
+		}
+	}
+
+	s := g.acquire()
+	defer g.release(s)
+
+	err := g.expand(ctx, s, g.reverse, end, func(int) float64 { return 0 }, func(node int) bool {
+		delete(pending, node)
+		return len(pending) == 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]*Route, len(from))
+	for idx, start := range starts {
+		if start == -1 || math.IsInf(s.seconds[start], 1) {
+			continue
+		}
+		// searching backwards, the previous node is the next one towards the end
+		var path []int
+		for node := start; node != -1; node = s.prev[node] {
+			path = append(path, node)
+		}
+		routes[idx] = g.newRoute(from[idx], to, path, s.km[start], s.seconds[start])
+	}
+	return routes, nil
+}
+
+// newRoute is the route along the path of nodes from the point to the other, km and seconds long on the roads
+func (g *Graph) newRoute(from, to Point, path []int, km, seconds float64) *Route {
+	// the legs from the points to the roads are driven slowly
+	access := Distance(from, g.nodes[path[0]]) + Distance(g.nodes[path[len(path)-1]], to)
+	route := &Route{
+		Points:     make([]Point, 0, len(path)+2),
+		DistanceKm: km + access,
+		Duration:   time.Duration(seconds*float64(time.Second)) + travel(access, SNAP_SPEED_KMH),
+	}
+
+	route.Points = append(route.Points, from)
+	for _, node := range path {
+		route.Points = append(route.Points, g.nodes[node])
+	}
+	route.Points = append(route.Points, to)
+
+	return route
+}
+
+// search returns the nodes of the fastest path from start to end, its km and its seconds
+func (g *Graph) search(ctx context.Context, start, end int) ([]int, float64, float64, error) {
+	s := g.acquire()
+	defer g.release(s)
+
+	err := g.expand(ctx, s, g.edges, start, func(node int) float64 { return g.estimate(node, end) }, func(node int) bool { return node == end })
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if math.IsInf(s.seconds[end], 1) {
+		return nil, 0, 0, ErrNoRoute
+	}
+
+	var path []int
+	for node := end; node != -1; node = s.prev[node] {
+		path = append(path, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, s.km[end], s.seconds[end], nil
+}
+
+// expand settles the nodes from start along the edges, the closest first, until done is true for a settled node
+// or all the reachable nodes are settled. estimate is the A* heuristic, Dijkstra without one.
+func (g *Graph) expand(ctx context.Context, s *searchState, edges [][]edge, start int, estimate func(node int) float64, done func(node int) bool) error {
+	s.reach(start, -1, 0, 0)
+	heap.Push(&s.queue, visit{node: start, priority: estimate(start)})
+
+	for settled := 1; s.queue.Len() > 0; settled++ {
+		if settled%CONTEXT_CHECK == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		node := heap.Pop(&s.queue).(visit).node
+		if s.closed[node] {
+			continue
+		}
+		s.closed[node] = true
+		if done(node) {
+			return nil
+		}
+
+		for _, e := range edges[node] {
+			if s.closed[e.to] {
+				continue
+			}
+			if cost := s.seconds[node] + e.seconds; cost < s.seconds[e.to] {
+				s.reach(e.to, node, cost, s.km[node]+e.km)
+				heap.Push(&s.queue, visit{node: e.to, priority: cost + estimate(e.to)})
+			}
+		}
+	}
+
+	return nil
+}
+
+// searchState is what a search knows about every node, it is reused by the next searches
+type searchState struct {
+	seconds []float64
+	km      []float64
+	prev    []int
+	closed  []bool
+	// the nodes reached, only they are reset for the next search
+	touched []int
+	queue   frontier
+}
+
+func (g *Graph) acquire() *searchState {
+	if s, ok := g.states.Get().(*searchState); ok && len(s.seconds) == len(g.nodes) {
+		return s
+	}
+
+	s := &searchState{
+		seconds: make([]float64, len(g.nodes)),
+		km:      make([]float64, len(g.nodes)),
+		prev:    make([]int, len(g.nodes)),
+		closed:  make([]bool, len(g.nodes)),
+	}
+	for idx := range s.seconds {
+		s.seconds[idx] = math.Inf(1)
+		s.prev[idx] = -1
+	}
+	return s
+}
+
+func (g *Graph) release(s *searchState) {
+	for _, node := range s.touched {
+		s.seconds[node] = math.Inf(1)
+		s.km[node] = 0
+		s.prev[node] = -1
+		s.closed[node] = false
+	}
+	s.touched = s.touched[:0]
+	s.queue = s.queue[:0]
+	g.states.Put(s)
+}
+
+// reach records a faster way to the node, from prev
+func (s *searchState) reach(node, prev int, seconds, km float64) {
+	if math.IsInf(s.seconds[node], 1) {
+		s.touched = append(s.touched, node)
+	}
+	s.seconds[node] = seconds
+	s.km[node] = km
+	s.prev[node] = prev
+}
+
+// estimate is the A* heuristic, the seconds it takes to drive straight from the node to the end on the fastest road
+func (g *Graph) estimate(node, end int) float64 {
+	if g.topSpeed == 0 {
+		return 0
+	}
+	return Distance(g.nodes[node], g.nodes[end]) / g.topSpeed * 3600
+}
+
+// index puts the nodes of the largest connected part of the roads in cells, the other parts
+// (parking lots, private roads cut off by the extract) would snap points to roads that lead nowhere.
+// It also reverses the roads.
+func (g *Graph) index() {
+	parent := make([]int, len(g.nodes))
+	for idx := range parent {
+		parent[idx] = idx
+	}
+	var root func(node int) int
+	root = func(node int) int {
+		if parent[node] != node {
+			parent[node] = root(parent[node])
+		}
+		return parent[node]
+	}
+	for from, edges := range g.edges {
+		for _, e := range edges {
+			parent[root(from)] = root(e.to)
+		}
+	}
+
+	sizes := make(map[int]int)
+	largest := -1
+	for node := range g.nodes {
+		// lone nodes are not roads
+		if len(g.edges[node]) == 0 {
+			continue
+		}
+		r := root(node)
+		sizes[r]++
+		if largest == -1 || sizes[r] > sizes[largest] {
+			largest = r
+		}
+	}
+
+	g.reverse = make([][]edge, len(g.nodes))
+	for from, edges := range g.edges {
+		for _, e := range edges {
+			g.reverse[e.to] = append(g.reverse[e.to], edge{to: from, km: e.km, seconds: e.seconds})
+		}
+	}
+
+	g.cells = make(map[cell][]int)
+	for node, p := range g.nodes {
+		if largest != -1 && root(node) == largest && sizes[largest] > 1 {
+			c := cellOf(p)
+			g.cells[c] = append(g.cells[c], node)
+		}
+	}
+}
+
+// snap returns the node closest to the point, searching the cells around it ring by ring
+func (g *Graph) snap(p Point) (int, bool) {
+	center := cellOf(p)
+	best, bestDistance, found := -1, math.Inf(1), -1
+
+	for ring := 0; ring <= SNAP_RINGS; ring++ {
+		for row := center.row - ring; row <= center.row+ring; row++ {
+			for column := center.column - ring; column <= center.column+ring; column++ {
+				// only the border of the ring, the inside was searched already
+				if row != center.row-ring && row != center.row+ring && column != center.column-ring && column != center.column+ring {
+					continue
+				}
+				for _, node := range g.cells[cell{row: row, column: column}] {
+					if distance := Distance(p, g.nodes[node]); distance < bestDistance {
+						best, bestDistance = node, distance
+					}
+				}
+			}
+		}
+		// a node of the next ring can still be closer than one found in a corner of this one
+		if found != -1 && ring > found {
+			break
+		}
+		if best != -1 && found == -1 {
+			found = ring
+		}
+	}
+
+	return best, best != -1
+}
+
+func cellOf(p Point) cell {
+	return cell{
+		row:    int(math.Floor(p.Latitude / CELL_SIZE)),
+		column: int(math.Floor(p.Longitude / CELL_SIZE)),
+	}
+}
+
+type visit struct {
+	node     int
+	priority float64
+}
+
+// frontier is the A* open set, the node with the lowest estimated total first
+type frontier []visit
+
+func (f frontier) Len() int            { return len(f) }
+func (f frontier) Less(i, j int) bool  { return f[i].priority < f[j].priority }
+func (f frontier) Swap(i, j int)       { f[i], f[j] = f[j], f[i] }
+func (f *frontier) Push(x interface{}) { *f = append(*f, x.(visit)) }
+func (f *frontier) Pop() interface{} {
+	old := *f
+	last := old[len(old)-1]
+	*f = old[:len(old)-1]
+	return last
+}
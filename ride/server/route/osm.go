@@ -0,0 +1,169 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/paulmach/osm"
+	"github.com/paulmach/osm/osmpbf"
+	"github.com/paulmach/osm/osmxml"
+)
+
+const KM_PER_MILE = 1.609344
+
+// SPEEDS are the km/h the cars drive at on every kind of road, the other highways (footways, cycleways, ...) are not driven
+var SPEEDS = map[string]float64{
+	"motorway":       90,
+	"motorway_link":  50,
+	"trunk":          70,
+	"trunk_link":     40,
+	"primary":        50,
+	"primary_link":   40,
+	"secondary":      50,
+	"secondary_link": 40,
+	"tertiary":       40,
+	"tertiary_link":  30,
+	"unclassified":   30,
+	"residential":    30,
+	"living_street":  10,
+	"service":        15,
+}
+
+// road is a way the cars can drive on
+type road struct {
+	nodes []osm.NodeID
+	speed float64
+	// 1 when the road is only driven in the order of its nodes, -1 when only in the reverse order
+	direction int
+}
+
+// LoadOSM builds the road network of an OpenStreetMap extract, a .osm.pbf or a .osm xml file
+func LoadOSM(ctx context.Context, path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var scanner osm.Scanner
+	if strings.HasSuffix(path, ".pbf") {
+		pbf := osmpbf.New(ctx, f, runtime.GOMAXPROCS(-1))
+		pbf.SkipRelations = true
+		scanner = pbf
+	} else {
+		scanner = osmxml.New(ctx, f)
+	}
+	defer scanner.Close()
+
+	// the extracts list the nodes before the ways, the nodes are kept until the ways tell which ones are on a road
+	points := make(map[osm.NodeID]Point)
+	var roads []road
+	for scanner.Scan() {
+		switch object := scanner.Object().(type) {
+		case *osm.Node:
+			points[object.ID] = Point{Latitude: object.Lat, Longitude: object.Lon}
+		case *osm.Way:
+			if r, ok := roadOf(object); ok {
+				roads = append(roads, r)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+
+	graph := NewGraph()
+	ids := make(map[osm.NodeID]int)
+	node := func(id osm.NodeID) (int, bool) {
+		if idx, ok := ids[id]; ok {
+			return idx, true
+		}
+		p, ok := points[id]
+		if !ok {
+			return 0, false
+		}
+		ids[id] = graph.AddNode(p)
+		return ids[id], true
+	}
+
+	for _, r := range roads {
+		for idx := 1; idx < len(r.nodes); idx++ {
+			// the ways crossing the border of the extract miss their nodes outside of it
+			from, ok := node(r.nodes[idx-1])
+			if !ok {
+				continue
+			}
+			to, ok := node(r.nodes[idx])
+			if !ok {
+				continue
+			}
+
+			if r.direction < 0 {
+				from, to = to, from
+			}
+			graph.AddRoad(from, to, r.speed, r.direction != 0)
+		}
+	}
+
+	return graph, nil
+}
+
+// roadOf tells whether the way is a road the cars can drive on, at which speed and in which direction
+func roadOf(way *osm.Way) (road, bool) {
+	highway := way.Tags.Find("highway")
+	speed, ok := SPEEDS[highway]
+	if !ok {
+		return road{}, false
+	}
+	switch way.Tags.Find("access") {
+	case "no", "private":
+		return road{}, false
+	}
+	if way.Tags.Find("area") == "yes" {
+		return road{}, false
+	}
+
+	if maxspeed, ok := parseMaxSpeed(way.Tags.Find("maxspeed")); ok {
+		speed = maxspeed
+	}
+
+	r := road{nodes: make([]osm.NodeID, len(way.Nodes)), speed: speed}
+	for idx, node := range way.Nodes {
+		r.nodes[idx] = node.ID
+	}
+
+	switch way.Tags.Find("oneway") {
+	case "yes", "true", "1":
+		r.direction = 1
+	case "-1", "reverse":
+		r.direction = -1
+	case "no", "false", "0":
+	default:
+		// the motorways and the roundabouts are oneway unless tagged otherwise
+		if highway == "motorway" || way.Tags.Find("junction") == "roundabout" {
+			r.direction = 1
+		}
+	}
+
+	return r, true
+}
+
+// parseMaxSpeed reads a maxspeed tag in km/h, like "50" or "30 mph", the zone values like "RO:urban" are skipped
+func parseMaxSpeed(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	unit := 1.0
+	if strings.HasSuffix(value, "mph") {
+		value = strings.TrimSpace(strings.TrimSuffix(value, "mph"))
+		unit = KM_PER_MILE
+	}
+
+	speed, err := strconv.ParseFloat(value, 64)
+	if err != nil || speed <= 0 {
+		return 0, false
+	}
+	return speed * unit, true
+}
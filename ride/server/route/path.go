@@ -0,0 +1,79 @@
+package route
+
+import (
+	"math"
+	"time"
+)
+
+// Deviation returns how far in km the point is from the closest segment of the route
+func (r *Route) Deviation(p Point) float64 {
+	_, _, km := r.closest(p)
+	return km
+}
+
+// Rest returns what is left of the route for a driver at the point, from the closest point of the route to its end.
+// Its distance and duration are the share of the route's ones left to drive.
+func (r *Route) Rest(p Point) *Route {
+	if len(r.Points) < 2 {
+		return r
+	}
+
+	segment, closest, _ := r.closest(p)
+	rest := &Route{Points: append([]Point{closest}, r.Points[segment+1:]...)}
+
+	total, left := length(r.Points), length(rest.Points)
+	if total == 0 {
+		return rest
+	}
+	rest.DistanceKm = r.DistanceKm * left / total
+	rest.Duration = time.Duration(float64(r.Duration) * left / total)
+	return rest
+}
+
+// closest returns the segment of the route closest to the point, the closest point on it and its distance in km
+func (r *Route) closest(p Point) (int, Point, float64) {
+	if len(r.Points) == 1 {
+		return 0, r.Points[0], Distance(p, r.Points[0])
+	}
+
+	// the segments are short enough to be flat, in km around the point
+	kmPerDegree := EARTH_RADIUS_KM * math.Pi / 180
+	scale := math.Cos(p.Latitude * math.Pi / 180)
+	flat := func(q Point) (float64, float64) {
+		return (q.Longitude - p.Longitude) * kmPerDegree * scale, (q.Latitude - p.Latitude) * kmPerDegree
+	}
+
+	best, bestPoint, bestDistance := 0, Point{}, math.Inf(1)
+	for idx := 1; idx < len(r.Points); idx++ {
+		a, b := r.Points[idx-1], r.Points[idx]
+		ax, ay := flat(a)
+		bx, by := flat(b)
+
+		// the point is the origin, t is where its projection falls on the segment
+		dx, dy := bx-ax, by-ay
+		t := 0.0
+		if squared := dx*dx + dy*dy; squared > 0 {
+			t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/squared))
+		}
+
+		// on a tie the driver is on the later segment, they are past the junction
+		if distance := math.Hypot(ax+t*dx, ay+t*dy); distance <= bestDistance {
+			best, bestDistance = idx-1, distance
+			bestPoint = Point{
+				Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+				Longitude: a.Longitude + t*(b.Longitude-a.Longitude),
+			}
+		}
+	}
+
+	return best, bestPoint, bestDistance
+}
+
+// length returns the km along the points
+func length(points []Point) float64 {
+	km := 0.0
+	for idx := 1; idx < len(points); idx++ {
+		km += Distance(points[idx-1], points[idx])
+	}
+	return km
+}
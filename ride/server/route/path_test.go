@@ -0,0 +1,50 @@
+package route
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDeviation(t *testing.T) {
+	// from 1 east to 3 then north to 9
+	junction3 := Point{Latitude: 47.160, Longitude: 27.584}
+	route := &Route{Points: []Point{junction1, junction3, junction9}}
+
+	tests := []struct {
+		name  string
+		point Point
+		want  float64
+	}{
+		{"on a junction", junction3, 0},
+		{"on a segment", Point{Latitude: 47.160, Longitude: 27.582}, 0},
+		{"beside a segment", Point{Latitude: 47.161, Longitude: 27.582}, Distance(Point{Latitude: 47.160, Longitude: 27.582}, Point{Latitude: 47.161, Longitude: 27.582})},
+		{"past the end", Point{Latitude: 47.165, Longitude: 27.584}, Distance(junction9, Point{Latitude: 47.165, Longitude: 27.584})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := route.Deviation(tt.point); math.Abs(got-tt.want) > 1e-3 {
+				t.Fatalf("Deviation() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRest(t *testing.T) {
+	junction3 := Point{Latitude: 47.160, Longitude: 27.584}
+	route := &Route{Points: []Point{junction1, junction3, junction9}, DistanceKm: 1, Duration: time.Minute}
+
+	rest := route.Rest(junction3)
+	if len(rest.Points) != 2 || rest.Points[1] != junction9 {
+		t.Fatalf("rest = %v, want from 3 to 9", rest.Points)
+	}
+
+	share := Distance(junction3, junction9) / (Distance(junction1, junction3) + Distance(junction3, junction9))
+	if math.Abs(rest.DistanceKm-share) > 1e-6 {
+		t.Fatalf("distance = %f, want %f", rest.DistanceKm, share)
+	}
+	if want := time.Duration(float64(time.Minute) * share); math.Abs(float64(rest.Duration-want)) > float64(time.Millisecond) {
+		t.Fatalf("duration = %v, want %v", rest.Duration, want)
+	}
+}
@@ -0,0 +1,97 @@
+package route
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// POLYLINE_PRECISION is the factor the coordinates are rounded with, 5 decimals like google maps
+const POLYLINE_PRECISION = 1e5
+
+var ErrInvalidPolyline = errors.New("invalid polyline")
+
+// Encode writes the points with the polyline algorithm of google maps:
+// every coordinate is the difference to the previous one, in chunks of 5 bits as printable characters
+func Encode(points []Point) string {
+	var b strings.Builder
+	lastLatitude, lastLongitude := 0, 0
+
+	for _, p := range points {
+		latitude := int(math.Round(p.Latitude * POLYLINE_PRECISION))
+		longitude := int(math.Round(p.Longitude * POLYLINE_PRECISION))
+
+		encodeValue(&b, latitude-lastLatitude)
+		encodeValue(&b, longitude-lastLongitude)
+		lastLatitude, lastLongitude = latitude, longitude
+	}
+
+	return b.String()
+}
+
+func encodeValue(b *strings.Builder, value int) {
+	// the sign goes in the lowest bit
+	shifted := value << 1
+	if value < 0 {
+		shifted = ^shifted
+	}
+
+	for shifted >= 0x20 {
+		b.WriteByte(byte((0x20 | (shifted & 0x1f)) + 63))
+		shifted >>= 5
+	}
+	b.WriteByte(byte(shifted + 63))
+}
+
+// Decode reads the points of a polyline written by Encode
+func Decode(polyline string) ([]Point, error) {
+	var points []Point
+	latitude, longitude := 0, 0
+
+	for idx := 0; idx < len(polyline); {
+		var deltaLatitude, deltaLongitude int
+		var err error
+
+		if deltaLatitude, idx, err = decodeValue(polyline, idx); err != nil {
+			return nil, err
+		}
+		if deltaLongitude, idx, err = decodeValue(polyline, idx); err != nil {
+			return nil, err
+		}
+
+		latitude += deltaLatitude
+		longitude += deltaLongitude
+		points = append(points, Point{
+			Latitude:  float64(latitude) / POLYLINE_PRECISION,
+			Longitude: float64(longitude) / POLYLINE_PRECISION,
+		})
+	}
+
+	return points, nil
+}
+
+// decodeValue reads the value starting at idx and returns the index after it
+func decodeValue(polyline string, idx int) (int, int, error) {
+	result, shift := 0, 0
+	for {
+		if idx >= len(polyline) {
+			return 0, 0, ErrInvalidPolyline
+		}
+		chunk := int(polyline[idx]) - 63
+		idx++
+		if chunk < 0 || chunk >= 0x40 {
+			return 0, 0, ErrInvalidPolyline
+		}
+
+		result |= (chunk & 0x1f) << shift
+		shift += 5
+		if chunk < 0x20 {
+			break
+		}
+	}
+
+	if result&1 != 0 {
+		return ^(result >> 1), idx, nil
+	}
+	return result >> 1, idx, nil
+}
@@ -0,0 +1,40 @@
+package route
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	// the example of the google maps documentation
+	points := []Point{{Latitude: 38.5, Longitude: -120.2}, {Latitude: 40.7, Longitude: -120.95}, {Latitude: 43.252, Longitude: -126.453}}
+
+	if got, want := Encode(points), "_p~iF~ps|U_ulLnnqC_mqNvxq`@"; got != want {
+		t.Fatalf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeReadsEncode(t *testing.T) {
+	points := []Point{junction1, junction9, parking, {Latitude: -33.86882, Longitude: 151.20929}}
+
+	decoded, err := Decode(Encode(points))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(points) {
+		t.Fatalf("decoded %d points, want %d", len(decoded), len(points))
+	}
+	for idx := range points {
+		if math.Abs(decoded[idx].Latitude-points[idx].Latitude) > 1e-5 || math.Abs(decoded[idx].Longitude-points[idx].Longitude) > 1e-5 {
+			t.Fatalf("point %d = %v, want %v", idx, decoded[idx], points[idx])
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	// the last chunk says more follow
+	if _, err := Decode("_p~iF~ps|U_"); !errors.Is(err, ErrInvalidPolyline) {
+		t.Fatalf("err = %v, want ErrInvalidPolyline", err)
+	}
+}
@@ -0,0 +1,100 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"go.uber.org/zap"
+)
+
+const (
+	EARTH_RADIUS_KM   = 6371.0
+	AVERAGE_SPEED_KMH = 30.0
+)
+
+var ErrNoRoute = errors.New("No route between the points")
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Route is the way a car drives from a point to another
+type Route struct {
+	// the geometry of the route, from the start to the end
+	Points []Point
+	// km along the roads
+	DistanceKm float64
+	// how long the drive takes at the speeds of the roads
+	Duration time.Duration
+}
+
+// Router finds the route between two points
+type Router interface {
+	Route(ctx context.Context, from, to Point) (*Route, error)
+	// Towards finds the routes from every point to the same end, the route of a point that can't reach it is nil
+	Towards(ctx context.Context, from []Point, to Point) ([]*Route, error)
+}
+
+// NewRouter loads the road map of the config, without one the routes are straight lines
+func NewRouter(log *zap.Logger, cfg *config.Config) (Router, error) {
+	if cfg.Ride.RouteMap == "" {
+		log.Info("No road map, routing in straight lines")
+		return StraightRouter{}, nil
+	}
+
+	started := time.Now()
+	graph, err := LoadOSM(context.Background(), cfg.Ride.RouteMap)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Loaded the road map",
+		zap.String("path", cfg.Ride.RouteMap),
+		zap.Int("nodes", graph.Nodes()),
+		zap.Int("edges", graph.Edges()),
+		zap.Duration("took", time.Since(started)),
+	)
+	return graph, nil
+}
+
+// StraightRouter drives in a straight line at the average city speed
+type StraightRouter struct{}
+
+func (StraightRouter) Route(ctx context.Context, from, to Point) (*Route, error) {
+	distance := Distance(from, to)
+
+	return &Route{
+		Points:     []Point{from, to},
+		DistanceKm: distance,
+		Duration:   travel(distance, AVERAGE_SPEED_KMH),
+	}, nil
+}
+
+func (s StraightRouter) Towards(ctx context.Context, from []Point, to Point) ([]*Route, error) {
+	routes := make([]*Route, len(from))
+	for idx, p := range from {
+		routes[idx], _ = s.Route(ctx, p, to)
+	}
+	return routes, nil
+}
+
+// Distance returns the great-circle distance in km between two points
+func Distance(a, b Point) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(b.Latitude - a.Latitude)
+	dLon := toRad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Latitude))*math.Cos(toRad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EARTH_RADIUS_KM * math.Asin(math.Sqrt(h))
+}
+
+// travel returns how long driving the km takes at the speed
+func travel(km, speed float64) time.Duration {
+	return time.Duration(km / speed * float64(time.Hour))
+}
@@ -0,0 +1,244 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// junctions of testdata/grid.osm
+var (
+	junction1 = Point{Latitude: 47.160, Longitude: 27.580}
+	junction4 = Point{Latitude: 47.162, Longitude: 27.580}
+	junction5 = Point{Latitude: 47.162, Longitude: 27.582}
+	junction6 = Point{Latitude: 47.162, Longitude: 27.584}
+	junction7 = Point{Latitude: 47.164, Longitude: 27.580}
+	junction8 = Point{Latitude: 47.164, Longitude: 27.582}
+	junction9 = Point{Latitude: 47.164, Longitude: 27.584}
+	parking   = Point{Latitude: 47.159, Longitude: 27.583}
+)
+
+func loadGrid(t *testing.T) *Graph {
+	t.Helper()
+
+	graph, err := LoadOSM(context.Background(), "testdata/grid.osm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func passes(route *Route, p Point) bool {
+	for _, point := range route.Points {
+		if point == p {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLoadOSM(t *testing.T) {
+	graph := loadGrid(t)
+
+	// the footway, the private road and the road leaving the extract are not driven
+	if nodes := graph.Nodes(); nodes != 11 {
+		t.Fatalf("nodes = %d, want 11", nodes)
+	}
+	if edges := graph.Edges(); edges != 22 {
+		t.Fatalf("edges = %d, want 22", edges)
+	}
+}
+
+func TestRouteFollowsOneways(t *testing.T) {
+	graph := loadGrid(t)
+
+	east, err := graph.Route(context.Background(), junction4, junction6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !passes(east, junction5) {
+		t.Fatalf("the route east %v does not take the oneway street", east.Points)
+	}
+
+	west, err := graph.Route(context.Background(), junction6, junction4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes(west, junction5) {
+		t.Fatalf("the route west %v takes the oneway street the wrong way", west.Points)
+	}
+	if west.DistanceKm <= east.DistanceKm {
+		t.Fatalf("the route west is %fkm, want a detour longer than the %fkm east", west.DistanceKm, east.DistanceKm)
+	}
+}
+
+func TestRouteIsTheFastest(t *testing.T) {
+	graph := loadGrid(t)
+
+	// the living street through 5 is shorter, the primary road through 9 is faster than the slow middle street
+	// and the northern street is oneway towards 7
+	route, err := graph.Route(context.Background(), junction1, junction8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes(route, junction5) || passes(route, junction7) || !passes(route, junction9) {
+		t.Fatalf("route = %v, want the one through 9", route.Points)
+	}
+
+	want := Distance(junction1, Point{Latitude: 47.160, Longitude: 27.584}) + Distance(junction6, junction9)*2 + Distance(junction9, junction8)
+	if math.Abs(route.DistanceKm-want) > 1e-6 {
+		t.Fatalf("distance = %f, want %f", route.DistanceKm, want)
+	}
+	if route.Duration <= 0 {
+		t.Fatalf("duration = %v, want a positive one", route.Duration)
+	}
+}
+
+func TestRouteSnapsToTheMainRoads(t *testing.T) {
+	graph := loadGrid(t)
+
+	// the parking lot's roads lead nowhere, the route starts on the closest road of the grid
+	route, err := graph.Route(context.Background(), parking, junction9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Points[0] != parking || route.Points[len(route.Points)-1] != junction9 {
+		t.Fatalf("route = %v, want it from the parking lot to 9", route.Points)
+	}
+	if passes(route, Point{Latitude: 47.159, Longitude: 27.5834}) {
+		t.Fatalf("route = %v, want it off the parking lot", route.Points)
+	}
+}
+
+func TestRouteOffTheMap(t *testing.T) {
+	graph := loadGrid(t)
+
+	bucharest := Point{Latitude: 44.43, Longitude: 26.10}
+	if _, err := graph.Route(context.Background(), junction1, bucharest); !errors.Is(err, ErrNoRoute) {
+		t.Fatalf("err = %v, want ErrNoRoute", err)
+	}
+}
+
+// shortest returns the seconds of the fastest paths between all the nodes, trying every intermediate node
+func shortest(g *Graph) [][]float64 {
+	seconds := make([][]float64, g.Nodes())
+	for from := range seconds {
+		seconds[from] = make([]float64, g.Nodes())
+		for to := range seconds[from] {
+			if from != to {
+				seconds[from][to] = math.Inf(1)
+			}
+		}
+		for _, e := range g.edges[from] {
+			seconds[from][e.to] = math.Min(seconds[from][e.to], e.seconds)
+		}
+	}
+
+	for via := range seconds {
+		for from := range seconds {
+			for to := range seconds {
+				seconds[from][to] = math.Min(seconds[from][to], seconds[from][via]+seconds[via][to])
+			}
+		}
+	}
+	return seconds
+}
+
+func TestSearchIsOptimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 20; round++ {
+		g := NewGraph()
+		for idx := 0; idx < 30; idx++ {
+			g.AddNode(Point{Latitude: 47.15 + random.Float64()*0.03, Longitude: 27.57 + random.Float64()*0.03})
+		}
+		for idx := 0; idx < 60; idx++ {
+			speeds := []float64{10, 30, 50, 90}
+			g.AddRoad(random.Intn(30), random.Intn(30), speeds[random.Intn(len(speeds))], random.Intn(3) == 0)
+		}
+		want := shortest(g)
+
+		for from := 0; from < g.Nodes(); from++ {
+			for to := 0; to < g.Nodes(); to++ {
+				_, _, seconds, err := g.search(context.Background(), from, to)
+				if math.IsInf(want[from][to], 1) {
+					if !errors.Is(err, ErrNoRoute) {
+						t.Fatalf("%d -> %d: err = %v, want ErrNoRoute", from, to, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%d -> %d: %v", from, to, err)
+				}
+				if math.Abs(seconds-want[from][to]) > 1e-6 {
+					t.Fatalf("%d -> %d: %fs, want %fs", from, to, seconds, want[from][to])
+				}
+			}
+		}
+	}
+}
+
+func TestTowardsMatchesRoute(t *testing.T) {
+	graph := loadGrid(t)
+
+	bucharest := Point{Latitude: 44.43, Longitude: 26.10}
+	from := []Point{junction1, junction4, junction6, junction7, junction9, parking, junction8, bucharest}
+	routes, err := graph.Towards(context.Background(), from, junction8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, p := range from[:len(from)-1] {
+		want, err := graph.Route(context.Background(), p, junction8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := routes[idx]
+		if got == nil || got.Duration != want.Duration || math.Abs(got.DistanceKm-want.DistanceKm) > 1e-6 {
+			t.Fatalf("%v: route = %+v, want %+v", p, got, want)
+		}
+		if got.Points[0] != p || got.Points[len(got.Points)-1] != junction8 {
+			t.Fatalf("%v: route = %v, want it from the point to 8", p, got.Points)
+		}
+	}
+	if routes[len(routes)-1] != nil {
+		t.Fatalf("route = %+v, want none off the map", routes[len(routes)-1])
+	}
+}
+
+func TestStraightRouter(t *testing.T) {
+	route, err := StraightRouter{}.Route(context.Background(), junction1, junction9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if route.DistanceKm != Distance(junction1, junction9) {
+		t.Fatalf("distance = %f, want %f", route.DistanceKm, Distance(junction1, junction9))
+	}
+	if want := time.Duration(route.DistanceKm / AVERAGE_SPEED_KMH * float64(time.Hour)); route.Duration != want {
+		t.Fatalf("duration = %v, want %v", route.Duration, want)
+	}
+}
+
+func TestParseMaxSpeed(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		ok    bool
+	}{
+		{"50", 50, true},
+		{"30 mph", 30 * KM_PER_MILE, true},
+		{"RO:urban", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		speed, ok := parseMaxSpeed(tt.value)
+		if ok != tt.ok || math.Abs(speed-tt.want) > 1e-9 {
+			t.Fatalf("parseMaxSpeed(%q) = %v, %v, want %v, %v", tt.value, speed, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	ridev1 "github.com/alexcogojocaru/cloud-computing-project/api/ride/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/store"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type activeRides struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+func newActiveRides() *activeRides {
+	return &activeRides{
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+func (a *activeRides) add(parent context.Context, rideID string) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cancels[rideID] = cancel
+
+	return ctx
+}
+
+func (a *activeRides) remove(rideID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if cancel, ok := a.cancels[rideID]; ok {
+		cancel()
+		delete(a.cancels, rideID)
+	}
+}
+
+// stop interrupts the ride loop, it returns false when the ride is not streamed by this instance
+func (a *activeRides) stop(rideID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cancel, ok := a.cancels[rideID]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+func (r *RideGrpcService) Cancel(ctx context.Context, req *ridev1.CancelRideRequest) (*ridev1.CancelRideResponse, error) {
+	r.log.Info("Received cancel request",
+		zap.String("method", "Cancel"),
+		zap.String("rideid", req.RideId),
+		zap.String("actor", req.Actor.String()),
+	)
+
+	ride, err := r.store.Get(ctx, req.RideId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := cancelActor(ctx, ride, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	ride, err = r.store.Transition(ctx, req.RideId, store.StateCancelled, func(ride *store.Ride) {
+		ride.CancelReason = req.Reason
+		ride.CancelledBy = actor.String()
+	})
+	var transitionErr *store.TransitionError
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.As(err, &transitionErr) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// instances that don't stream the ride notice the cancellation on their next state change
+	if !r.active.stop(ride.ID) {
+		r.log.Info("Ride is not streamed by this instance", zap.String("rideid", ride.ID))
+	}
+
+	if ride.Driver != "" {
+		r.release(ride.Driver)
+	}
+
+	r.notify(ctx, events.RideNotification{
+		Event:      events.RIDE_CANCELLED,
+		RideID:     ride.ID,
+		RiderName:  ride.Rider,
+		DriverName: ride.Driver,
+		Distance:   ride.Distance,
+		Reason:     ride.CancelReason,
+		Actor:      ride.CancelledBy,
+		Timestamp:  time.Now(),
+	})
+
+	return &ridev1.CancelRideResponse{
+		RideId: ride.ID,
+		State:  ridev1.RideState_CANCELLED,
+	}, nil
+}
+
+// cancelActor checks that riders and drivers only cancel their own rides and records them as the actor,
+// whatever the request says. Services and admins cancel any ride as the actor they name.
+func cancelActor(ctx context.Context, ride *store.Ride, requested ridev1.CancelActor) (ridev1.CancelActor, error) {
+	identity, _ := interceptor.FromContext(ctx)
+
+	switch identity.Role {
+	case token.RoleRider:
+		if ride.Rider != identity.Subject {
+			return requested, interceptor.ErrNotOwner
+		}
+		return ridev1.CancelActor_RIDER, nil
+	case token.RoleDriver:
+		if ride.Driver == "" || ride.Driver != identity.Subject {
+			return requested, interceptor.ErrNotOwner
+		}
+		return ridev1.CancelActor_DRIVER, nil
+	case token.RoleService, token.RoleAdmin:
+		return requested, nil
+	default:
+		return requested, interceptor.ErrNotAllowed
+	}
+}
@@ -2,28 +2,69 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"cloud.google.com/go/firestore"
-	firebase "firebase.google.com/go"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/store"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
-type FirestoreWrapper struct {
-	Client *firestore.Client
+// emulatorCredentials authenticate to the firestore emulator as the owner, so the security rules don't apply
+type emulatorCredentials struct{}
+
+func (emulatorCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer owner"}, nil
 }
 
-func NewFirestoreDb() *FirestoreWrapper {
-	conf := &firebase.Config{ProjectID: "cloudcomputing-386413"}
-	app, err := firebase.NewApp(context.Background(), conf)
-	if err != nil {
-		return nil
+func (emulatorCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func newFirestoreClient(ctx context.Context, cfg *config.Config) (*firestore.Client, error) {
+	if cfg.GCP.FirestoreEmulatorHost == "" {
+		return firestore.NewClient(ctx, cfg.GCP.Project)
 	}
 
-	client, err := app.Firestore(context.Background())
+	conn, err := grpc.Dial(
+		cfg.GCP.FirestoreEmulatorHost,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(emulatorCredentials{}),
+	)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	return &FirestoreWrapper{
-		Client: client,
+	return firestore.NewClient(ctx, cfg.GCP.Project, option.WithGRPCConn(conn))
+}
+
+// NewRideStore picks the ride store from the config, the firestore client is only created when it is used
+func NewRideStore(lc fx.Lifecycle, log *zap.Logger, rdb *redis.Client, cfg *config.Config) (store.Store, error) {
+	log.Info("Using ride store", zap.String("store", cfg.Ride.Store))
+
+	switch cfg.Ride.Store {
+	case config.RIDE_STORE_REDIS:
+		return store.NewRedisStore(rdb), nil
+	case config.RIDE_STORE_MEMORY:
+		return store.NewMemoryStore(), nil
+	case config.RIDE_STORE_FIRESTORE:
+		client, err := newFirestoreClient(context.Background(), cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		lc.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				return client.Close()
+			},
+		})
+		return store.NewFirestoreStore(client), nil
+	default:
+		return nil, fmt.Errorf("unknown ride store %q", cfg.Ride.Store)
 	}
 }
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	ridev1 "github.com/alexcogojocaru/cloud-computing-project/api/ride/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/pricing"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/store"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (r *RideGrpcService) Estimate(ctx context.Context, req *ridev1.EstimateRequest) (*ridev1.EstimateResponse, error) {
+	r.log.Info("Received estimate request", zap.String("method", "Estimate"))
+
+	if req.StartLocation == nil || req.EndLocation == nil {
+		return nil, status.Error(codes.InvalidArgument, "the start and the end locations are required")
+	}
+	start := route.Point{Latitude: req.StartLocation.Latitude, Longitude: req.StartLocation.Longitude}
+	end := route.Point{Latitude: req.EndLocation.Latitude, Longitude: req.EndLocation.Longitude}
+
+	city, ok := r.pricer.City(start)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, pricing.ErrNoRateCard.Error())
+	}
+
+	vehicles := pricing.Vehicles(city)
+	if len(req.VehicleTypes) > 0 {
+		vehicles = make([]string, len(req.VehicleTypes))
+		for idx, vehicle := range req.VehicleTypes {
+			vehicles[idx] = vehicleClass(vehicle)
+		}
+	}
+
+	trip := r.route(ctx, start, end)
+
+	resp := &ridev1.EstimateResponse{Route: newPlannedRoute(trip)}
+	for _, vehicle := range vehicles {
+		fare, err := r.pricer.Fare(start, vehicle, trip.DistanceKm, trip.Duration)
+		if err != nil {
+			// the city doesn't price the class
+			continue
+		}
+		resp.Fares = append(resp.Fares, newFare(fare))
+	}
+	if len(resp.Fares) == 0 {
+		return nil, status.Error(codes.InvalidArgument, pricing.ErrNoRateCard.Error())
+	}
+
+	return resp, nil
+}
+
+// vehicleClass names the vehicle type like the rate cards do
+func vehicleClass(vehicle driverv1.VehicleType) string {
+	return strings.ToLower(vehicle.String())
+}
+
+func newFare(fare pricing.Fare) *ridev1.Fare {
+	return &ridev1.Fare{
+		Currency:             fare.Currency,
+		City:                 fare.City,
+		Vehicle:              driverv1.VehicleType(driverv1.VehicleType_value[strings.ToUpper(fare.Vehicle)]),
+		BaseFare:             fare.BaseFare,
+		DistanceFare:         fare.DistanceFare,
+		TimeFare:             fare.TimeFare,
+		MinimumFareSurcharge: fare.MinimumFareSurcharge,
+		BookingFee:           fare.BookingFee,
+		Total:                fare.Total,
+	}
+}
+
+func newStoreFare(fare pricing.Fare) *store.Fare {
+	return &store.Fare{
+		Currency:             fare.Currency,
+		City:                 fare.City,
+		Vehicle:              fare.Vehicle,
+		BaseFare:             fare.BaseFare,
+		DistanceFare:         fare.DistanceFare,
+		TimeFare:             fare.TimeFare,
+		MinimumFareSurcharge: fare.MinimumFareSurcharge,
+		BookingFee:           fare.BookingFee,
+		Total:                fare.Total,
+	}
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/dispatch"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/store"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DEFAULT_SEARCH_RADIUS = 1.0
+	RADIUS_GROWTH         = 2.0
+	// how many of the closest free drivers are ranked in a round
+	MAX_CANDIDATES = 20
+	// the pause before a round that left the ride no driver to reserve is tried again
+	RETRY_DELAY = 100 * time.Millisecond
+)
+
+var ErrNoDriver = errors.New("No free drivers")
+
+// match offers the ride to the best ranked free drivers, one at a time, until one of them accepts.
+// Every round picks the drivers of the ride and reserves the first one that is still free,
+// they stay reserved while the offer is pending so that no other ride can take them.
+// When no candidate is left in the search radius, the radius grows up to maxSearchRadius, which also caps the rider's one.
+func (r *RideGrpcService) match(ctx context.Context, ride *store.Ride, location *driverv1.LocationMetadata) (*driverv1.DriverLocation, error) {
+	radius := location.Radius
+	if radius <= 0 {
+		radius = DEFAULT_SEARCH_RADIUS
+	}
+	// a wider radius asked by the rider is still searched once
+	if radius > r.maxSearchRadius {
+		radius = r.maxSearchRadius
+	}
+
+	var tried []string
+	for radius <= r.maxSearchRadius {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		ranked, found, err := r.choose(ctx, ride, location, radius, tried)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			radius *= RADIUS_GROWTH
+			r.log.Info("Widening the search radius", zap.String("rideid", ride.ID), zap.Float64("radius", radius))
+			continue
+		}
+		if len(ranked) == 0 {
+			// the other rides of the batch got all the drivers around, the next batch tries again
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(RETRY_DELAY):
+			}
+			continue
+		}
+
+		// not bound to ctx, a reservation made while the ride gets cancelled must not be lost,
+		// but a driver service that doesn't answer must not hold the matching forever
+		reserveCtx, cancel := context.WithTimeout(context.Background(), ROLLBACK_TIMEOUT)
+		candidate, err := r.driverClient.Reserve(reserveCtx, &driverv1.ReserveRequest{
+			Location: &driverv1.LocationMetadata{
+				Latitude:  location.Latitude,
+				Longitude: location.Longitude,
+				Radius:    radius,
+			},
+			Exclude: tried,
+			Names:   ranked,
+		})
+		cancel()
+		if status.Code(err) == codes.NotFound {
+			// the ranked drivers were all taken meanwhile, the next round looks past them
+			tried = append(tried, ranked...)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tried = append(tried, candidate.Name)
+
+		if r.offer(ctx, ride, candidate) {
+			return candidate, nil
+		}
+
+		r.release(candidate.Name)
+	}
+
+	return nil, ErrNoDriver
+}
+
+// choose returns the drivers to reserve for the ride in this round, in order, and whether any driver is around the pickup.
+// The greedy matching ranks the drivers for the ride alone, the batch one waits for the driver assigned to the ride
+// together with the other rides of the batch.
+func (r *RideGrpcService) choose(ctx context.Context, ride *store.Ride, pickup *driverv1.LocationMetadata, radius float64, tried []string) ([]string, bool, error) {
+	req := dispatch.Request{
+		RideID:  ride.ID,
+		Pickup:  rank.Point{Latitude: pickup.Latitude, Longitude: pickup.Longitude},
+		Radius:  radius,
+		Exclude: tried,
+	}
+
+	if r.batcher == nil {
+		candidates, err := r.find(ctx, req)
+		if err != nil || len(candidates) == 0 {
+			return nil, false, err
+		}
+
+		scores := r.ranker.Rank(req.Pickup, candidates)
+		r.recordRanking(ctx, ride.ID, radius, scores)
+
+		names := make([]string, len(scores))
+		for idx, score := range scores {
+			names[idx] = score.Driver
+		}
+		return names, true, nil
+	}
+
+	assignment, err := r.batcher.Submit(ctx, req)
+	if err != nil || len(assignment.Scores) == 0 {
+		return nil, false, err
+	}
+
+	r.recordRanking(ctx, ride.ID, radius, assignment.Scores)
+	r.log.Info("Assigned driver", zap.String("rideid", ride.ID), zap.String("drivername", assignment.Driver))
+
+	if assignment.Driver == "" {
+		return nil, true, nil
+	}
+	return []string{assignment.Driver}, true, nil
+}
+
+// recordRanking keeps the scores of the drivers on the ride so that the dispatch can be explained
+func (r *RideGrpcService) recordRanking(ctx context.Context, rideID string, radius float64, scores []rank.Score) {
+	ranking := store.Ranking{
+		Radius:     radius,
+		RankedAt:   time.Now(),
+		Candidates: make([]store.CandidateScore, len(scores)),
+	}
+	for idx, score := range scores {
+		ranking.Candidates[idx] = store.CandidateScore{Driver: score.Driver, Total: score.Total, Factors: score.Factors}
+	}
+
+	r.log.Info("Ranked drivers", zap.String("rideid", rideID), zap.Any("scores", scores))
+
+	_, err := r.store.Update(ctx, rideID, func(ride *store.Ride) error {
+		ride.Rankings = append(ride.Rankings, ranking)
+		return nil
+	})
+	if err != nil {
+		r.log.Error("Cannot record the ranking", zap.String("rideid", rideID), zap.Error(err))
+	}
+}
+
+// find returns the free drivers around the pickup with their road distance to it and their ratings and acceptance stats,
+// the drivers are ranked on the defaults when their stats can't be read
+func (r *RideGrpcService) find(ctx context.Context, req dispatch.Request) ([]rank.Candidate, error) {
+	found, err := r.driverClient.GetClosest(ctx, &driverv1.GetClosestRequest{
+		Latitude:   req.Pickup.Latitude,
+		Longitude:  req.Pickup.Longitude,
+		Radius:     req.Radius,
+		MaxResults: MAX_CANDIDATES,
+		Status:     driverv1.DriverStatus_FREE,
+		Exclude:    req.Exclude,
+	})
+	if err != nil {
+		return nil, err
+	}
+	locations := found.Locations
+
+	pipe := r.rdb.Pipeline()
+	offers := make([]*redis.SliceCmd, len(locations))
+	ratings := make([]*redis.SliceCmd, len(locations))
+	for idx, location := range locations {
+		offers[idx] = pipe.HMGet(ctx, offerStatsKey(location.Name), "offered", string(store.OfferAccepted))
+		ratings[idx] = pipe.HMGet(ctx, ratingsKey(location.Name), "sum", "count")
+	}
+	if len(locations) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			r.log.Error("Cannot read the drivers' stats", zap.Error(err))
+		}
+	}
+
+	points := make([]route.Point, len(locations))
+	for idx, location := range locations {
+		points[idx] = pointOf(location)
+	}
+	drives := r.towards(ctx, points, route.Point(req.Pickup))
+
+	now := time.Now()
+	candidates := make([]rank.Candidate, len(locations))
+	for idx, location := range locations {
+		candidates[idx] = rank.Candidate{
+			Name:     location.Name,
+			Point:    rank.Point{Latitude: location.Latitude, Longitude: location.Longitude},
+			Distance: drives[idx].DistanceKm,
+			Heading:  location.Heading,
+		}
+		if location.FreeSince > 0 {
+			candidates[idx].Idle = now.Sub(time.UnixMilli(location.FreeSince))
+		}
+		if values, err := offers[idx].Result(); err == nil {
+			candidates[idx].Offered, candidates[idx].Accepted = counter(values[0]), counter(values[1])
+		}
+		if values, err := ratings[idx].Result(); err == nil {
+			candidates[idx].RatingSum, candidates[idx].RatingCount = counter(values[0]), counter(values[1])
+		}
+	}
+
+	return candidates, nil
+}
+
+// counter reads a hash field incremented with HINCRBY, a missing field is 0
+func counter(value interface{}) int64 {
+	text, _ := value.(string)
+	count, _ := strconv.ParseInt(text, 10, 64)
+	return count
+}
+
+// offer proposes the ride to the driver and records their answer, it reports whether they accepted
+func (r *RideGrpcService) offer(ctx context.Context, ride *store.Ride, candidate *driverv1.DriverLocation) bool {
+	offer := store.Offer{
+		Driver:    candidate.Name,
+		Distance:  candidate.Distance,
+		OfferedAt: time.Now(),
+	}
+
+	offerCtx, cancel := context.WithTimeout(ctx, r.offerTimeout)
+	defer cancel()
+
+	reply, err := r.driverClient.Offer(offerCtx, &driverv1.RideOffer{
+		Driver:  candidate.Name,
+		RideId:  ride.ID,
+		Rider:   ride.Rider,
+		Pickup:  &driverv1.LocationMetadata{Latitude: ride.Start.Latitude, Longitude: ride.Start.Longitude},
+		Dropoff: &driverv1.LocationMetadata{Latitude: ride.End.Latitude, Longitude: ride.End.Longitude},
+	})
+	offer.RespondedAt = time.Now()
+
+	switch {
+	case err == nil && reply.Decision == driverv1.OfferDecision_ACCEPT:
+		offer.Outcome = store.OfferAccepted
+	case err == nil:
+		offer.Outcome = store.OfferDeclined
+	case status.Code(err) == codes.DeadlineExceeded && ctx.Err() == nil:
+		offer.Outcome = store.OfferTimeout
+	case status.Code(err) == codes.Unavailable:
+		offer.Outcome = store.OfferUnavailable
+	default:
+		offer.Outcome = store.OfferFailed
+	}
+
+	r.log.Info("Offer",
+		zap.String("rideid", ride.ID),
+		zap.String("drivername", candidate.Name),
+		zap.String("outcome", string(offer.Outcome)),
+		zap.Error(err),
+	)
+
+	r.recordOffer(ride.ID, offer)
+
+	return offer.Outcome == store.OfferAccepted
+}
+
+func offerStatsKey(drivername string) string {
+	return fmt.Sprintf("drivers/offers/%s", drivername)
+}
+
+// ratingsKey is a hash of the sum of the stars the riders gave the driver and of their count.
+// Nothing writes it yet, the rating weight is 0 by default until the rating flow of the app does.
+func ratingsKey(drivername string) string {
+	return fmt.Sprintf("drivers/ratings/%s", drivername)
+}
+
+// recordOffer keeps the offer on the ride and counts it in the driver's acceptance stats
+func (r *RideGrpcService) recordOffer(rideID string, offer store.Offer) {
+	ctx, cancel := context.WithTimeout(context.Background(), ROLLBACK_TIMEOUT)
+	defer cancel()
+
+	_, err := r.store.Update(ctx, rideID, func(ride *store.Ride) error {
+		ride.Offers = append(ride.Offers, offer)
+		return nil
+	})
+	if err != nil {
+		r.log.Error("Cannot record the offer", zap.String("rideid", rideID), zap.Error(err))
+	}
+
+	pipe := r.rdb.TxPipeline()
+	pipe.HIncrBy(ctx, offerStatsKey(offer.Driver), "offered", 1)
+	pipe.HIncrBy(ctx, offerStatsKey(offer.Driver), string(offer.Outcome), 1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.log.Error("Cannot count the offer", zap.String("drivername", offer.Driver), zap.Error(err))
+	}
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+)
+
+// policy lists the roles allowed to call every rpc, the handlers check that riders and drivers only act on their own rides
+var policy = interceptor.Policy{
+	"/ride.v1.Ride/Start":    {token.RoleRider, token.RoleAdmin},
+	"/ride.v1.Ride/Cancel":   {token.RoleRider, token.RoleDriver, token.RoleService, token.RoleAdmin},
+	"/ride.v1.Ride/Estimate": {token.RoleRider, token.RoleAdmin},
+}
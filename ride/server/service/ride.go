@@ -2,82 +2,124 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"net"
-	"os"
 	"time"
 
-	"cloud.google.com/go/pubsub"
-	"github.com/alexcogojocaru/cloud-computing-project/ride/proto-gen/pb"
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	ridev1 "github.com/alexcogojocaru/cloud-computing-project/api/ride/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/dispatch"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/pricing"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/store"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 type RideGrpcService struct {
-	pb.UnimplementedRideServer
+	ridev1.UnimplementedRideServer
 
 	log          *zap.Logger
 	driverConn   *grpc.ClientConn
-	driverClient pb.DriverClient
+	driverClient driverv1.DriverClient
 	rdb          *redis.Client
-	pubsub       *pubsub.Client
-	firestore    *FirestoreWrapper
+	publisher    messaging.Publisher
+	store        store.Store
+	ranker       rank.Ranker
+	router       route.Router
+	pricer       *pricing.Pricer
+	// set when the rides are matched in batches
+	batcher *dispatch.Batcher
+
+	// cancel functions of the rides streamed by this instance
+	active *activeRides
+	// how long a tick of the simulation lasts, it drives SIMULATED_TICK of the route
+	tick time.Duration
+	// how long a driver has to answer an offer
+	offerTimeout time.Duration
+	// matching gives up past this radius, in km
+	maxSearchRadius float64
+	// how far in km the driver can stray before the leg is routed again, 0 never does
+	offRouteKm float64
 }
 
-type NotificationMessage struct {
-	RideID     string    `json:"rideid"`
-	RiderName  string    `json:"rider"`
-	DriverName string    `json:"driver"`
-	Distance   float64   `json:"distance"`
-	Timestamp  time.Time `json:"timestamp"`
-}
-
-var (
-	DRIVER_ADDR = os.Getenv("DRIVER_ADDR")
+const (
+	ROLLBACK_TIMEOUT = 10 * time.Second
+	// how far in km the driver can be from the route before it is planned again, more than the gps noise
+	OFF_ROUTE_KM = 0.1
+	// how much of the drive a tick of the simulation stands for
+	SIMULATED_TICK = time.Minute
 )
 
 func NewRideGrpcService(
 	lc fx.Lifecycle,
 	log *zap.Logger,
-) *RideGrpcService {
-	if DRIVER_ADDR == "" {
-		DRIVER_ADDR = "localhost:8081"
-	}
-
-	conn, err := grpc.Dial(DRIVER_ADDR, grpc.WithInsecure())
-	if err != nil {
-		return nil
-	}
-
-	pubsubClient, err := pubsub.NewClient(context.Background(), "cloudcomputing-386413")
+	rdb *redis.Client,
+	rideStore store.Store,
+	ranker rank.Ranker,
+	router route.Router,
+	pricer *pricing.Pricer,
+	tokens *token.Manager,
+	publisher messaging.Publisher,
+	cfg *config.Config,
+) (*RideGrpcService, error) {
+	// calls to the driver service are made as the ride service, not on behalf of the rider
+	conn, err := grpc.Dial(
+		cfg.Ride.DriverAddr,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(interceptor.NewServiceCredentials(tokens, "ride-server")),
+	)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	client := pb.NewDriverClient(conn)
+	client := driverv1.NewDriverClient(conn)
 	r := &RideGrpcService{
 		log:          log,
 		driverConn:   conn,
 		driverClient: client,
-		rdb:          NewRedisClient(),
-		pubsub:       pubsubClient,
-		firestore:    NewFirestoreDb(),
+		rdb:          rdb,
+		publisher:    publisher,
+		store:        rideStore,
+		ranker:       ranker,
+		router:       router,
+		pricer:       pricer,
+		active:       newActiveRides(),
+		tick:         1 * time.Second,
+
+		offerTimeout:    cfg.Ride.OfferTimeout,
+		maxSearchRadius: cfg.Ride.MaxSearchRadius,
+	}
+	// a straight line is no road to stay on, the drivers take the streets around it
+	if _, straight := router.(route.StraightRouter); !straight {
+		r.offRouteKm = OFF_ROUTE_KM
+	}
+	if cfg.Ride.Dispatch == config.DISPATCH_BATCH {
+		r.batcher = dispatch.NewBatcher(log, cfg.Ride.BatchWindow, ranker, r.find)
 	}
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			lis, err := net.Listen("tcp", ":8082")
+			lis, err := net.Listen("tcp", cfg.Addr())
 			if err != nil {
-				log.Fatal("Cannot listen to port 8082", zap.Error(err))
+				log.Fatal("Cannot listen", zap.String("addr", cfg.Addr()), zap.Error(err))
 			}
 
-			server := grpc.NewServer()
-			pb.RegisterRideServer(server, r)
+			server := grpc.NewServer(
+				grpc.UnaryInterceptor(interceptor.Unary(tokens, policy)),
+				grpc.StreamInterceptor(interceptor.Stream(tokens, policy)),
+			)
+			ridev1.RegisterRideServer(server, r)
 
 			go func() {
 				log.Info("Starting grpc service...")
@@ -94,133 +136,405 @@ func NewRideGrpcService(
 		},
 	})
 
-	return r
+	return r, nil
 }
 
-func (r *RideGrpcService) Start(location *pb.StartRideRequest, stream pb.Ride_StartServer) error {
-	ctx := context.Background()
+func (r *RideGrpcService) Start(location *ridev1.StartRideRequest, stream ridev1.Ride_StartServer) error {
 	r.log.Info("Received start request", zap.String("method", "Start"))
-	driverData, err := r.driverClient.GetClosest(ctx, location.StartLocation)
+
+	if !interceptor.Owns(stream.Context(), location.Username) {
+		return interceptor.ErrNotOwner
+	}
+
+	ride := store.NewRide(
+		uuid.New().String(),
+		location.Username,
+		store.Location{
+			Latitude:  location.StartLocation.Latitude,
+			Longitude: location.StartLocation.Longitude,
+		},
+		store.Location{
+			Latitude:  location.EndLocation.Latitude,
+			Longitude: location.EndLocation.Longitude,
+		},
+	)
+	if err := r.store.Create(stream.Context(), ride); err != nil {
+		return err
+	}
+
+	// ctx ends when the rider hangs up, the deadline passes or the Cancel rpc is called
+	ctx := r.active.add(stream.Context(), ride.ID)
+	defer r.active.remove(ride.ID)
+
+	err := stream.Send(&ridev1.StartRideResponse{
+		Matched: false,
+		RideId:  ride.ID,
+		State:   ridev1.RideState_REQUESTED,
+	})
 	if err != nil {
+		return r.stopped(ctx, ride, nil, stream, err)
+	}
+
+	closestDriver, err := r.match(ctx, ride, location.StartLocation)
+	if err == ErrNoDriver {
+		r.store.Transition(ctx, ride.ID, store.StateCancelled, func(ride *store.Ride) {
+			ride.CancelReason = "no free drivers"
+			ride.CancelledBy = ridev1.CancelActor_SYSTEM.String()
+		})
 		return err
 	}
+	if err != nil {
+		return r.stopped(ctx, ride, nil, stream, err)
+	}
 
-	if len(driverData.Locations) == 0 {
-		return errors.New("No drivers available")
+	r.log.Info("Closest driver", zap.Any("driver", closestDriver))
+
+	ride, err = r.advance(ctx, ride, store.StateMatched, func(ride *store.Ride) {
+		ride.Driver = closestDriver.Name
+	})
+	if err != nil {
+		// the ride did not get the driver, give them back
+		r.release(closestDriver.Name)
+		return r.stopped(ctx, ride, nil, stream, err)
 	}
 
-	r.log.Info("Received driver data", zap.Int("size", len(driverData.Locations)))
+	r.log.Info("Matched ride",
+		zap.String("rideid", ride.ID),
+		zap.String("rider", location.Username),
+		zap.String("driver", closestDriver.Name),
+	)
 
-	var closestDriver *pb.DriverLocation
-	for _, driver := range driverData.Locations {
-		metadata, err := r.driverClient.GetStatus(ctx, &pb.DriverStatusMetadata{
-			Name: driver.Name,
-		})
-		if err != nil {
-			r.log.Fatal(
-				"Cannot retrieve the driver's status",
-				zap.String("drivername", driver.Name),
-				zap.Error(err),
-			)
-		}
+	pickup := r.route(ctx, pointOf(closestDriver), route.Point(ride.Start))
 
-		r.log.Info("Driver metadata", zap.Any("driver", metadata))
+	// the rider can draw the way to the pickup from the first matched response
+	matched := newStartRideResponse(ride, closestDriver, pickup)
+	matched.Route = newPlannedRoute(pickup)
+	err = stream.Send(matched)
+	if err != nil {
+		return r.stopped(ctx, ride, closestDriver, stream, err)
+	}
 
-		if metadata.Status == pb.DriverStatus_FREE {
-			closestDriver = driver
-			break
-		}
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+	driver := &tracker{
+		location: closestDriver,
+		updates:  r.watch(watchCtx, closestDriver.Name),
 	}
 
-	r.log.Info("Closest driver", zap.Any("driver", closestDriver))
+	// the driver drives to the pickup point
+	ride, err = r.advance(ctx, ride, store.StateDriverEnRoute, nil)
+	if err != nil {
+		return r.stopped(ctx, ride, closestDriver, stream, err)
+	}
 
-	if closestDriver == nil {
-		return errors.New("No free drivers")
+	_, err = r.simulate(ctx, ride, driver, ride.Start, pickup, stream)
+	if err != nil {
+		return r.stopped(ctx, ride, closestDriver, stream, err)
 	}
 
-	r.driverClient.SetStatus(ctx, &pb.DriverStatusMetadata{
-		Name:   closestDriver.Name,
-		Status: pb.DriverStatus_BUSY,
-	})
+	trip := r.route(ctx, route.Point(ride.Start), route.Point(ride.End))
+
+	r.log.Info("Computed the ride route", zap.Float64("distance", trip.DistanceKm), zap.Duration("duration", trip.Duration))
+
+	ride, err = r.advance(ctx, ride, store.StateInProgress, nil)
+	if err != nil {
+		return r.stopped(ctx, ride, closestDriver, stream, err)
+	}
+
+	driven, err := r.simulate(ctx, ride, driver, ride.End, trip, stream)
+	if err != nil {
+		return r.stopped(ctx, ride, closestDriver, stream, err)
+	}
 
-	r.rdb.GeoAdd(ctx, "riders/location", &redis.GeoLocation{
-		Name:      fmt.Sprintf("start-%s", location.Username),
-		Longitude: location.StartLocation.Longitude,
-		Latitude:  location.StartLocation.Latitude,
-	}, &redis.GeoLocation{
-		Name:      fmt.Sprintf("stop-%s", location.Username),
-		Longitude: location.EndLocation.Longitude,
-		Latitude:  location.EndLocation.Latitude,
+	// the ride is charged for the km and the minutes driven from the pickup, with the rate card of the driver's vehicle class
+	fare, priceErr := r.pricer.Fare(route.Point(ride.Start), vehicleClass(closestDriver.Vehicle), driven.km, driven.duration)
+	if priceErr != nil {
+		r.log.Error("Cannot price the ride", zap.String("rideid", ride.ID), zap.Error(priceErr))
+	}
+
+	ride, err = r.advance(ctx, ride, store.StateCompleted, func(ride *store.Ride) {
+		ride.Distance = driven.km
+		ride.DurationSeconds = int64(driven.duration.Seconds())
+		if priceErr == nil {
+			ride.Fare = newStoreFare(fare)
+		}
 	})
+	if err != nil {
+		return r.stopped(ctx, ride, closestDriver, stream, err)
+	}
 
-	r.log.Info("Starting ride",
+	fields := []zap.Field{
+		zap.String("rideid", ride.ID),
 		zap.String("rider", location.Username),
 		zap.String("driver", closestDriver.Name),
-	)
+		zap.Float64("distance", driven.km),
+		zap.Duration("duration", driven.duration),
+	}
+	if priceErr == nil {
+		fields = append(fields, zap.Int64("fare", fare.Total))
+	}
+	r.log.Info("Finished ride", fields...)
+
+	r.release(closestDriver.Name)
+
+	notification := events.RideNotification{
+		Event:      events.RIDE_COMPLETED,
+		RideID:     ride.ID,
+		RiderName:  location.Username,
+		DriverName: closestDriver.Name,
+		Distance:   driven.km,
+		Timestamp:  time.Now(),
+	}
+	if priceErr == nil {
+		notification.Fare, notification.Currency = fare.Total, fare.Currency
+	}
+	r.notify(ctx, notification)
+
+	completed := newStartRideResponse(ride, driver.location, r.route(ctx, pointOf(driver.location), route.Point(ride.End)))
+	if priceErr == nil {
+		completed.Fare = newFare(fare)
+	}
+	return stream.Send(completed)
+}
 
-	rideId := uuid.New().String()
+// advance moves the ride to the next state, on failure it returns the last known ride
+func (r *RideGrpcService) advance(ctx context.Context, ride *store.Ride, to store.State, fn func(*store.Ride)) (*store.Ride, error) {
+	next, err := r.store.Transition(ctx, ride.ID, to, fn)
+	if err != nil {
+		return ride, err
+	}
+
+	return next, nil
+}
+
+// stopped ends the stream of a ride that could not go on.
+// A ride cancelled through the Cancel rpc, here or on another instance, is not an error
+// for the rider: they get a last CANCELLED response instead.
+// Any other failure, the rider hanging up included, aborts the ride.
+func (r *RideGrpcService) stopped(ctx context.Context, ride *store.Ride, driver *driverv1.DriverLocation, stream ridev1.Ride_StartServer, err error) error {
+	if streamErr := stream.Context().Err(); streamErr != nil {
+		r.abort(ride, driver, streamErr)
+		return status.FromContextError(streamErr).Err()
+	}
+
+	var transitionErr *store.TransitionError
+	if ctx.Err() == nil && !(errors.As(err, &transitionErr) && transitionErr.From == store.StateCancelled) {
+		r.abort(ride, driver, err)
+		return err
+	}
 
-	stream.Send(&pb.StartRideResponse{
-		Matched:  true,
-		Location: closestDriver,
+	r.log.Info("Ride cancelled", zap.String("rideid", ride.ID))
+
+	return stream.Send(&ridev1.StartRideResponse{
+		Matched:  driver != nil,
+		Location: driver,
+		RideId:   ride.ID,
+		State:    ridev1.RideState_CANCELLED,
 	})
+}
+
+// abort persists the ride as ABORTED and frees its driver.
+// It runs on its own context since the ride's one is usually done by now.
+func (r *RideGrpcService) abort(ride *store.Ride, driver *driverv1.DriverLocation, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ROLLBACK_TIMEOUT)
+	defer cancel()
 
-	startName := fmt.Sprintf("start-%s", location.Username)
-	stopName := fmt.Sprintf("stop-%s", location.Username)
+	r.log.Warn("Aborting ride", zap.String("rideid", ride.ID), zap.Error(cause))
 
-	distance, err := r.rdb.GeoDist(context.Background(), "riders/location", startName, stopName, "km").Result()
+	_, err := r.store.Transition(ctx, ride.ID, store.StateAborted, func(ride *store.Ride) {
+		ride.CancelReason = cause.Error()
+		ride.CancelledBy = ridev1.CancelActor_SYSTEM.String()
+	})
 	if err != nil {
-		r.log.Error("Cannot compute the distance", zap.Error(err))
+		// the ride already ended, its driver is not ours to free anymore
+		r.log.Error("Cannot abort the ride", zap.String("rideid", ride.ID), zap.Error(err))
+		return
 	}
 
-	r.log.Info("Computed the ride distance", zap.Float64("distance", distance))
+	if driver != nil {
+		r.release(driver.Name)
+	}
+}
 
-	for i := 0; i < int(distance); i++ {
-		err = stream.Send(&pb.StartRideResponse{
-			Matched:  true,
-			Location: closestDriver,
-		})
+func (r *RideGrpcService) release(drivername string) {
+	ctx, cancel := context.WithTimeout(context.Background(), ROLLBACK_TIMEOUT)
+	defer cancel()
+
+	_, err := r.driverClient.SetStatus(ctx, &driverv1.DriverStatusMetadata{
+		Name:   drivername,
+		Status: driverv1.DriverStatus_FREE,
+	})
+	if err != nil {
+		r.log.Error("Cannot free the driver", zap.String("drivername", drivername), zap.Error(err))
+	}
+}
+
+func (r *RideGrpcService) notify(ctx context.Context, msg events.RideNotification) {
+	serverid, err := events.Publish(ctx, r.publisher, events.RideNotifications, msg)
+	if err != nil {
+		r.log.Error("Cannot publish the notification", zap.String("rideid", msg.RideID), zap.Error(err))
+		return
+	}
+	r.log.Info("PubSub id", zap.String("id", serverid), zap.String("event", msg.Event))
+}
+
+// tracker holds the last known location of the ride's driver
+type tracker struct {
+	location *driverv1.DriverLocation
+	updates  <-chan *driverv1.DriverLocation
+}
+
+// watch streams the driver's live locations until ctx is done
+func (r *RideGrpcService) watch(ctx context.Context, drivername string) <-chan *driverv1.DriverLocation {
+	updates := make(chan *driverv1.DriverLocation)
+
+	go func() {
+		defer close(updates)
+
+		stream, err := r.driverClient.Watch(ctx, &driverv1.WatchRequest{Name: drivername})
+		if err != nil {
+			r.log.Error("Cannot watch the driver", zap.String("drivername", drivername), zap.Error(err))
+			return
+		}
+
+		for {
+			location, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					r.log.Error("Stopped watching the driver", zap.String("drivername", drivername), zap.Error(err))
+				}
+				return
+			}
+
+			select {
+			case updates <- location:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates
+}
+
+// drive is how far and how long the driver drove on a leg
+type drive struct {
+	km       float64
+	duration time.Duration
+}
+
+// simulate streams the driver's location to the rider every tick and as soon as the driver moves.
+// The leg towards target lasts a tick per SIMULATED_TICK of its route's duration. The first response of the leg has its route,
+// when the driver strays farther than offRouteKm from it the leg is routed again from where they are and the new route is sent.
+// It returns what was driven on the simulated clock: the routes up to where they were left and all of the last one.
+func (r *RideGrpcService) simulate(ctx context.Context, ride *store.Ride, driver *tracker, target store.Location, leg *route.Route, stream ridev1.Ride_StartServer) (drive, error) {
+	ticker := time.NewTicker(r.tick)
+	defer ticker.Stop()
+
+	var driven drive
+	plan, remaining := leg, leg
+	announce, rerouted := true, false
+	// the response is sent before checking whether the leg is driven, a leg shorter than a tick still has its route
+	for elapsed := time.Duration(0); ; {
+		resp := newStartRideResponse(ride, driver.location, remaining)
+		if announce {
+			resp.Route = newPlannedRoute(plan)
+			resp.Rerouted = rerouted
+			announce = false
+		}
+		err := stream.Send(resp)
 		if err != nil {
-			return err
+			return driven, err
+		}
+		if elapsed >= plan.Duration {
+			driven.km += plan.DistanceKm
+			driven.duration += plan.Duration
+			break
 		}
 
 		r.log.Info("Ongoing ride",
-			zap.String("rider", location.Username),
-			zap.String("driver", closestDriver.Name),
+			zap.String("rideid", ride.ID),
+			zap.String("state", string(ride.State)),
+			zap.String("rider", ride.Rider),
+			zap.String("driver", ride.Driver),
 		)
 
-		time.Sleep(1 * time.Second)
+		select {
+		case <-ctx.Done():
+			return driven, ctx.Err()
+		case location, ok := <-driver.updates:
+			if !ok {
+				// no more live updates, keep going with the last known location
+				driver.updates = nil
+				continue
+			}
+			driver.location = location
+
+			position := pointOf(location)
+			if deviation := plan.Deviation(position); r.offRouteKm > 0 && deviation > r.offRouteKm {
+				// the route was driven up to the last point on it, then off it to where the driver is
+				driven.km += plan.DistanceKm - remaining.DistanceKm + route.Distance(remaining.Points[0], position)
+				driven.duration += elapsed
+				plan = r.route(ctx, position, route.Point(target))
+				remaining = plan
+				announce, rerouted = true, true
+				elapsed = 0
+				r.log.Info("Rerouted", zap.String("rideid", ride.ID), zap.String("drivername", location.Name), zap.Float64("deviation", deviation))
+				continue
+			}
+			remaining = plan.Rest(position)
+		case <-ticker.C:
+			elapsed += SIMULATED_TICK
+		}
 	}
 
-	r.log.Info("Finished ride",
-		zap.String("rider", location.Username),
-		zap.String("driver", closestDriver.Name),
-	)
-
-	r.driverClient.SetStatus(ctx, &pb.DriverStatusMetadata{
-		Name:   closestDriver.Name,
-		Status: pb.DriverStatus_FREE,
-	})
+	return driven, nil
+}
 
-	msg := NotificationMessage{
-		RideID:     rideId,
-		RiderName:  location.Username,
-		DriverName: closestDriver.Name,
-		Distance:   distance,
-		Timestamp:  time.Now(),
+// route returns the route between the points, a straight one when the router has none
+func (r *RideGrpcService) route(ctx context.Context, from, to route.Point) *route.Route {
+	found, err := r.router.Route(ctx, from, to)
+	if err != nil {
+		r.log.Warn("Cannot route, going in a straight line", zap.Any("from", from), zap.Any("to", to), zap.Error(err))
+		found, _ = route.StraightRouter{}.Route(ctx, from, to)
 	}
-	details, _ := json.Marshal(msg)
-	topic := r.pubsub.Topic("notification-stream")
-	res := topic.Publish(ctx, &pubsub.Message{
-		Data: details,
-	})
-	serverid, _ := res.Get(ctx)
-	r.log.Info("PubSub id", zap.String("id", serverid))
+	return found
+}
 
-	_, _, err = r.firestore.Client.Collection("rides").Add(ctx, msg)
+// towards returns the routes from every point to the same end, straight ones for the points the router has none for
+func (r *RideGrpcService) towards(ctx context.Context, from []route.Point, to route.Point) []*route.Route {
+	found, err := r.router.Towards(ctx, from, to)
 	if err != nil {
-		return err
+		r.log.Warn("Cannot route, going in straight lines", zap.Any("to", to), zap.Error(err))
+		found = make([]*route.Route, len(from))
 	}
+	for idx, drive := range found {
+		if drive == nil {
+			found[idx], _ = route.StraightRouter{}.Route(ctx, from[idx], to)
+		}
+	}
+	return found
+}
+
+func pointOf(location *driverv1.DriverLocation) route.Point {
+	return route.Point{Latitude: location.Latitude, Longitude: location.Longitude}
+}
 
-	return nil
+func newPlannedRoute(plan *route.Route) *ridev1.PlannedRoute {
+	return &ridev1.PlannedRoute{
+		Polyline:   route.Encode(plan.Points),
+		Distance:   plan.DistanceKm,
+		EtaSeconds: int64(plan.Duration.Seconds()),
+	}
+}
+
+func newStartRideResponse(ride *store.Ride, driver *driverv1.DriverLocation, remaining *route.Route) *ridev1.StartRideResponse {
+	return &ridev1.StartRideResponse{
+		Matched:           true,
+		Location:          driver,
+		RideId:            ride.ID,
+		State:             ridev1.RideState(ridev1.RideState_value[string(ride.State)]),
+		RemainingDistance: remaining.DistanceKm,
+		EtaSeconds:        int64(remaining.Duration.Seconds()),
+	}
 }
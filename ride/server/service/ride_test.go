@@ -0,0 +1,1004 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	driverv1 "github.com/alexcogojocaru/cloud-computing-project/api/driver/v1"
+	ridev1 "github.com/alexcogojocaru/cloud-computing-project/api/ride/v1"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/interceptor"
+	"github.com/alexcogojocaru/cloud-computing-project/auth/token"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/config"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/events"
+	"github.com/alexcogojocaru/cloud-computing-project/pkg/messaging"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/dispatch"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/pricing"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/rank"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/route"
+	"github.com/alexcogojocaru/cloud-computing-project/ride/store"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeRouter makes the road from where a driver stands as long as their Distance, the other roads are straight
+type fakeRouter struct {
+	drivers *fakeDriverClient
+}
+
+func (f fakeRouter) Route(ctx context.Context, from, to route.Point) (*route.Route, error) {
+	for _, driver := range f.drivers.drivers {
+		if driver.Latitude == from.Latitude && driver.Longitude == from.Longitude {
+			return &route.Route{
+				Points:     []route.Point{from, to},
+				DistanceKm: driver.Distance,
+				Duration:   time.Duration(driver.Distance / route.AVERAGE_SPEED_KMH * float64(time.Hour)),
+			}, nil
+		}
+	}
+	return route.StraightRouter{}.Route(ctx, from, to)
+}
+
+func (f fakeRouter) Towards(ctx context.Context, from []route.Point, to route.Point) ([]*route.Route, error) {
+	routes := make([]*route.Route, len(from))
+	for idx, p := range from {
+		routes[idx], _ = f.Route(ctx, p, to)
+	}
+	return routes, nil
+}
+
+// fakeDriverClient hands out its drivers in order and records the status updates it receives.
+// Drivers accept offers unless they are listed in declines or silent.
+type fakeDriverClient struct {
+	driverv1.DriverClient
+
+	drivers   []*driverv1.DriverLocation
+	declines  map[string]bool
+	silent    map[string]bool
+	locations chan *driverv1.DriverLocation
+
+	mu       sync.Mutex
+	statuses []driverv1.DriverStatus
+	freed    []string
+}
+
+func (f *fakeDriverClient) GetClosest(ctx context.Context, in *driverv1.GetClosestRequest, opts ...grpc.CallOption) (*driverv1.DriverLocationList, error) {
+	excluded := make(map[string]bool)
+	for _, name := range in.Exclude {
+		excluded[name] = true
+	}
+
+	found := &driverv1.DriverLocationList{}
+	for _, driver := range f.drivers {
+		if !excluded[driver.Name] {
+			found.Locations = append(found.Locations, driver)
+		}
+	}
+	return found, nil
+}
+
+// Reserve hands out the first driver named in the request
+func (f *fakeDriverClient) Reserve(ctx context.Context, in *driverv1.ReserveRequest, opts ...grpc.CallOption) (*driverv1.DriverLocation, error) {
+	excluded := make(map[string]bool)
+	for _, name := range in.Exclude {
+		excluded[name] = true
+	}
+
+	for _, name := range in.Names {
+		for _, driver := range f.drivers {
+			if driver.Name == name && !excluded[name] {
+				// only what the driver service sets on a reservation
+				return &driverv1.DriverLocation{
+					Name:      driver.Name,
+					Latitude:  driver.Latitude,
+					Longitude: driver.Longitude,
+					Distance:  driver.Distance,
+					Vehicle:   driver.Vehicle,
+				}, nil
+			}
+		}
+	}
+	return nil, status.Error(codes.NotFound, "no driver available")
+}
+
+func (f *fakeDriverClient) Offer(ctx context.Context, in *driverv1.RideOffer, opts ...grpc.CallOption) (*driverv1.OfferReply, error) {
+	if f.silent[in.Driver] {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	decision := driverv1.OfferDecision_ACCEPT
+	if f.declines[in.Driver] {
+		decision = driverv1.OfferDecision_DECLINE
+	}
+	return &driverv1.OfferReply{OfferId: in.OfferId, Decision: decision}, nil
+}
+
+func (f *fakeDriverClient) SetStatus(ctx context.Context, in *driverv1.DriverStatusMetadata, opts ...grpc.CallOption) (*driverv1.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses = append(f.statuses, in.Status)
+	if in.Status == driverv1.DriverStatus_FREE {
+		f.freed = append(f.freed, in.Name)
+	}
+	return &driverv1.Empty{}, nil
+}
+
+func (f *fakeDriverClient) Watch(ctx context.Context, in *driverv1.WatchRequest, opts ...grpc.CallOption) (driverv1.Driver_WatchClient, error) {
+	return &fakeWatchClient{ctx: ctx, locations: f.locations}, nil
+}
+
+type fakeWatchClient struct {
+	grpc.ClientStream
+
+	ctx       context.Context
+	locations chan *driverv1.DriverLocation
+}
+
+func (f *fakeWatchClient) Recv() (*driverv1.DriverLocation, error) {
+	select {
+	case location := <-f.locations:
+		return location, nil
+	case <-f.ctx.Done():
+		return nil, f.ctx.Err()
+	}
+}
+
+func (f *fakeDriverClient) released() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, s := range f.statuses {
+		if s == driverv1.DriverStatus_FREE {
+			count++
+		}
+	}
+	return count
+}
+
+type testEnv struct {
+	service *RideGrpcService
+	client  ridev1.RideClient
+	drivers *fakeDriverClient
+	rdb     *redis.Client
+	lis     *bufconn.Listener
+	tokens  *token.Manager
+}
+
+func newTestEnv(t *testing.T, driverDistance float64) *testEnv {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	drivers := &fakeDriverClient{
+		drivers: []*driverv1.DriverLocation{{
+			Name:      "driver-1",
+			Latitude:  47.16,
+			Longitude: 27.59,
+			Distance:  driverDistance,
+		}},
+		declines:  make(map[string]bool),
+		silent:    make(map[string]bool),
+		locations: make(chan *driverv1.DriverLocation),
+	}
+
+	cfg := config.Defaults(8082)
+	r := &RideGrpcService{
+		log:          zap.NewNop(),
+		driverClient: drivers,
+		rdb:          rdb,
+		publisher:    messaging.NewMemory(events.Topology()),
+		store:        store.NewRedisStore(rdb),
+		ranker:       rank.NewRanker(&cfg),
+		router:       fakeRouter{drivers: drivers},
+		pricer:       pricing.NewPricer(&cfg),
+		active:       newActiveRides(),
+		tick:         10 * time.Millisecond,
+
+		offerTimeout:    50 * time.Millisecond,
+		maxSearchRadius: 10,
+		offRouteKm:      OFF_ROUTE_KM,
+	}
+
+	tokens := token.NewManager([]byte("test-secret"), time.Minute)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary(tokens, policy)),
+		grpc.StreamInterceptor(interceptor.Stream(tokens, policy)),
+	)
+	ridev1.RegisterRideServer(server, r)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	env := &testEnv{
+		service: r,
+		drivers: drivers,
+		rdb:     rdb,
+		lis:     lis,
+		tokens:  tokens,
+	}
+	env.client = env.dial(t, "rider", token.RoleRider)
+
+	return env
+}
+
+// dial connects to the ride service as the given identity, an empty subject connects without a token
+func (env *testEnv) dial(t *testing.T, subject string, role token.Role) ridev1.RideClient {
+	t.Helper()
+
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return env.lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if subject != "" {
+		signed, _, err := env.tokens.Issue(subject, role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(interceptor.Bearer(signed)))
+	}
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return ridev1.NewRideClient(conn)
+}
+
+func startRideRequest() *ridev1.StartRideRequest {
+	return &ridev1.StartRideRequest{
+		Username: "rider",
+		StartLocation: &driverv1.LocationMetadata{
+			Latitude:  47.16129960502986,
+			Longitude: 27.590637972547764,
+			Radius:    2,
+		},
+		EndLocation: &driverv1.LocationMetadata{
+			Latitude:  47.172983080034896,
+			Longitude: 27.54453466623929,
+			Radius:    2,
+		},
+	}
+}
+
+// recvUntil reads the stream until a response in the given state arrives
+func recvUntil(t *testing.T, stream ridev1.Ride_StartClient, state ridev1.RideState) *ridev1.StartRideResponse {
+	t.Helper()
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("waiting for %s: %v", state, err)
+		}
+		if resp.State == state {
+			return resp
+		}
+	}
+}
+
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (env *testEnv) rideState(t *testing.T, rideID string) store.State {
+	t.Helper()
+
+	ride, err := env.service.store.Get(context.Background(), rideID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ride.State
+}
+
+func TestStartCompletesRide(t *testing.T) {
+	env := newTestEnv(t, 2)
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := recvUntil(t, stream, ridev1.RideState_REQUESTED)
+	if first.RideId == "" {
+		t.Fatal("the first response has no ride id")
+	}
+	last := recvUntil(t, stream, ridev1.RideState_COMPLETED)
+	if last.RideId != first.RideId {
+		t.Fatalf("ride id changed from %s to %s", first.RideId, last.RideId)
+	}
+
+	if state := env.rideState(t, first.RideId); state != store.StateCompleted {
+		t.Fatalf("ride state = %s, want %s", state, store.StateCompleted)
+	}
+	if released := env.drivers.released(); released != 1 {
+		t.Fatalf("driver released %d times, want 1", released)
+	}
+
+	// the ride is driven on the route between its points
+	ride, err := env.service.store.Get(context.Background(), first.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trip, _ := route.StraightRouter{}.Route(context.Background(), route.Point(ride.Start), route.Point(ride.End))
+	if ride.Distance != trip.DistanceKm || ride.DurationSeconds != int64(trip.Duration.Seconds()) {
+		t.Fatalf("ride distance = %f and duration = %ds, want %f and %ds", ride.Distance, ride.DurationSeconds, trip.DistanceKm, int64(trip.Duration.Seconds()))
+	}
+
+	// and charged for it with the rate card of the driver's vehicle
+	fare, err := env.service.pricer.Fare(route.Point(ride.Start), config.VEHICLE_STANDARD, trip.DistanceKm, trip.Duration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.Fare == nil || ride.Fare.Total != fare.Total || ride.Fare.Vehicle != config.VEHICLE_STANDARD {
+		t.Fatalf("ride fare = %+v, want %+v", ride.Fare, fare)
+	}
+	if last.Fare == nil || last.Fare.Total != fare.Total || last.Fare.Currency != "RON" {
+		t.Fatalf("completed response fare = %+v, want %+v", last.Fare, fare)
+	}
+}
+
+func TestStartChargesTheVehicleOfTheDriver(t *testing.T) {
+	env := newTestEnv(t, 2)
+	env.drivers.drivers[0].Vehicle = driverv1.VehicleType_XL
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := recvUntil(t, stream, ridev1.RideState_COMPLETED)
+
+	ride, err := env.service.store.Get(context.Background(), last.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fare, err := env.service.pricer.Fare(route.Point(ride.Start), config.VEHICLE_XL, ride.Distance, time.Duration(ride.DurationSeconds)*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.Fare == nil || ride.Fare.Vehicle != config.VEHICLE_XL || math.Abs(float64(ride.Fare.Total-fare.Total)) > 1 {
+		t.Fatalf("ride fare = %+v, want the xl one of about %d", ride.Fare, fare.Total)
+	}
+	if last.Fare == nil || last.Fare.Vehicle != driverv1.VehicleType_XL {
+		t.Fatalf("completed response fare = %+v, want the xl one", last.Fare)
+	}
+}
+
+// notifications subscribes to the ride notifications the service publishes from now on,
+// they have no subscription of their own, the consumers are outside of the repo
+func (env *testEnv) notifications(t *testing.T) <-chan events.RideNotification {
+	t.Helper()
+
+	topic := events.RideNotifications
+	topic.Subscription = "notification-stream-test"
+	broker := messaging.NewMemory(messaging.Topology{Subscriptions: map[string]string{topic.Subscription: topic.Name}})
+	env.service.publisher = broker
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	received := make(chan events.RideNotification, 16)
+	go events.Subscribe(ctx, broker, topic, func(ctx context.Context, d *events.Delivery[events.RideNotification], err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		d.Ack()
+		received <- d.Event
+	})
+	return received
+}
+
+func TestStartCompletesAnUnpricedRide(t *testing.T) {
+	env := newTestEnv(t, 2)
+	cfg := config.Defaults(8082)
+	cfg.Ride.Pricing.Cities = nil
+	env.service.pricer = pricing.NewPricer(&cfg)
+	notifications := env.notifications(t)
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := recvUntil(t, stream, ridev1.RideState_COMPLETED)
+	if last.Fare != nil {
+		t.Fatalf("completed response fare = %+v, want none", last.Fare)
+	}
+
+	ride, err := env.service.store.Get(context.Background(), last.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.Fare != nil {
+		t.Fatalf("ride fare = %+v, want none", ride.Fare)
+	}
+
+	var notification events.RideNotification
+	for notification.Event != events.RIDE_COMPLETED {
+		select {
+		case notification = <-notifications:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no completed notification")
+		}
+	}
+	if notification.RideID != last.RideId || notification.Fare != 0 || notification.Currency != "" {
+		t.Fatalf("notification = %+v, want the ride without a fare", notification)
+	}
+}
+
+func TestStartChargesTheDetour(t *testing.T) {
+	env := newTestEnv(t, 0.01)
+	// slow enough for the detour to be seen before the trip ends
+	env.service.tick = 50 * time.Millisecond
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvUntil(t, stream, ridev1.RideState_IN_PROGRESS)
+
+	astray := &driverv1.DriverLocation{Name: "driver-1", Latitude: 47.17, Longitude: 27.6}
+	env.drivers.locations <- astray
+	last := recvUntil(t, stream, ridev1.RideState_COMPLETED)
+
+	// the driver went off to the north east and was routed again from there
+	ride, err := env.service.store.Get(context.Background(), last.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, end := route.Point(ride.Start), route.Point(ride.End)
+	detour, _ := route.StraightRouter{}.Route(context.Background(), pointOf(astray), end)
+	if want := route.Distance(start, pointOf(astray)) + detour.DistanceKm; math.Abs(ride.Distance-want) > 1e-6 {
+		t.Fatalf("ride distance = %f, want the %fkm driven", ride.Distance, want)
+	}
+	if ride.DurationSeconds < int64(detour.Duration.Seconds()) {
+		t.Fatalf("ride duration = %ds, want at least the %ds of the new route", ride.DurationSeconds, int64(detour.Duration.Seconds()))
+	}
+
+	fare, err := env.service.pricer.Fare(start, config.VEHICLE_STANDARD, ride.Distance, time.Duration(ride.DurationSeconds)*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.Fare == nil || math.Abs(float64(ride.Fare.Total-fare.Total)) > 1 {
+		t.Fatalf("ride fare = %+v, want about %d", ride.Fare, fare.Total)
+	}
+}
+
+func TestStartRollsBackWhenRiderHangsUp(t *testing.T) {
+	env := newTestEnv(t, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := env.client.Start(ctx, startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+	cancel()
+
+	eventually(t, "the ride to be aborted", func() bool {
+		return env.rideState(t, matched.RideId) == store.StateAborted
+	})
+	eventually(t, "the driver to be released", func() bool {
+		return env.drivers.released() == 1
+	})
+}
+
+func TestStartRollsBackOnDeadline(t *testing.T) {
+	env := newTestEnv(t, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	stream, err := env.client.Start(ctx, startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("stream ended with %v, want DeadlineExceeded", err)
+	}
+
+	eventually(t, "the ride to be aborted", func() bool {
+		return env.rideState(t, matched.RideId) == store.StateAborted
+	})
+	eventually(t, "the driver to be released", func() bool {
+		return env.drivers.released() == 1
+	})
+}
+
+func TestStartStreamsLiveDriverLocation(t *testing.T) {
+	env := newTestEnv(t, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := env.client.Start(ctx, startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recvUntil(t, stream, ridev1.RideState_DRIVER_EN_ROUTE)
+	env.drivers.locations <- &driverv1.DriverLocation{
+		Name:      "driver-1",
+		Latitude:  47.17,
+		Longitude: 27.6,
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Location.Latitude != 47.17 {
+			continue
+		}
+
+		want := route.Distance(route.Point{Latitude: 47.17, Longitude: 27.6}, route.Point{
+			Latitude:  startRideRequest().StartLocation.Latitude,
+			Longitude: startRideRequest().StartLocation.Longitude,
+		})
+		if resp.RemainingDistance != want {
+			t.Fatalf("remaining distance = %f, want %f", resp.RemainingDistance, want)
+		}
+		if resp.EtaSeconds <= 0 {
+			t.Fatalf("eta = %d, want a positive value", resp.EtaSeconds)
+		}
+		return
+	}
+}
+
+func TestStartSendsPlannedRoute(t *testing.T) {
+	env := newTestEnv(t, 2)
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+	if matched.Route == nil {
+		t.Fatal("the matched response has no route")
+	}
+	if matched.Route.Distance != 2 || matched.Route.EtaSeconds <= 0 {
+		t.Fatalf("route = %+v, want the 2km to the pickup with an eta", matched.Route)
+	}
+
+	points, err := route.Decode(matched.Route.Polyline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pickup := startRideRequest().StartLocation
+	last := points[len(points)-1]
+	if len(points) < 2 || math.Abs(last.Latitude-pickup.Latitude) > 1e-5 || math.Abs(last.Longitude-pickup.Longitude) > 1e-5 {
+		t.Fatalf("route points = %v, want them to end at the pickup", points)
+	}
+
+	// the trip to the destination is planned when it starts
+	started := recvUntil(t, stream, ridev1.RideState_IN_PROGRESS)
+	if started.Route == nil || started.Rerouted {
+		t.Fatalf("the first in progress response has route %+v, rerouted %v, want the planned trip", started.Route, started.Rerouted)
+	}
+}
+
+func TestStartReroutesWhenDriverStrays(t *testing.T) {
+	env := newTestEnv(t, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := env.client.Start(ctx, startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvUntil(t, stream, ridev1.RideState_DRIVER_EN_ROUTE)
+
+	// halfway on the planned route, the rest of it is left to drive
+	pickup := startRideRequest().StartLocation
+	halfway := &driverv1.DriverLocation{Name: "driver-1", Latitude: (47.16 + pickup.Latitude) / 2, Longitude: (27.59 + pickup.Longitude) / 2}
+	env.drivers.locations <- halfway
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Location.Latitude != halfway.Latitude {
+			continue
+		}
+		if resp.Route != nil || resp.Rerouted {
+			t.Fatalf("the driver on the route was rerouted: %+v", resp.Route)
+		}
+		if math.Abs(resp.RemainingDistance-500) > 1 {
+			t.Fatalf("remaining distance = %f, want about half of the 1000km", resp.RemainingDistance)
+		}
+		break
+	}
+
+	// off the route, it is planned again from where the driver is
+	astray := &driverv1.DriverLocation{Name: "driver-1", Latitude: 47.17, Longitude: 27.6}
+	env.drivers.locations <- astray
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Location.Latitude != astray.Latitude {
+			continue
+		}
+		if !resp.Rerouted || resp.Route == nil {
+			t.Fatalf("the driver off the route was not rerouted: %+v", resp)
+		}
+		points, err := route.Decode(resp.Route.Polyline)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(points[0].Latitude-astray.Latitude) > 1e-5 || math.Abs(points[0].Longitude-astray.Longitude) > 1e-5 {
+			t.Fatalf("the new route starts at %v, want where the driver is", points[0])
+		}
+		return
+	}
+}
+
+func TestStartSendsTheRouteOfAShortLeg(t *testing.T) {
+	// the driver is seconds away from the pickup, less than a tick
+	env := newTestEnv(t, 0.01)
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enRoute := recvUntil(t, stream, ridev1.RideState_DRIVER_EN_ROUTE)
+	if enRoute.Route == nil || enRoute.Route.Distance != 0.01 {
+		t.Fatalf("the en route response has route %+v, want the 0.01km to the pickup", enRoute.Route)
+	}
+}
+
+func TestStartDoesNotRerouteStraightLines(t *testing.T) {
+	env := newTestEnv(t, 1000)
+	env.service.offRouteKm = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := env.client.Start(ctx, startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvUntil(t, stream, ridev1.RideState_DRIVER_EN_ROUTE)
+
+	astray := &driverv1.DriverLocation{Name: "driver-1", Latitude: 47.17, Longitude: 27.6}
+	env.drivers.locations <- astray
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Location.Latitude != astray.Latitude {
+			continue
+		}
+		if resp.Route != nil || resp.Rerouted {
+			t.Fatalf("the driver off the straight line was rerouted: %+v", resp.Route)
+		}
+		return
+	}
+}
+
+func TestCancelStopsRide(t *testing.T) {
+	env := newTestEnv(t, 1000)
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+
+	resp, err := env.client.Cancel(context.Background(), &ridev1.CancelRideRequest{
+		RideId: matched.RideId,
+		Reason: "changed my mind",
+		Actor:  ridev1.CancelActor_RIDER,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.State != ridev1.RideState_CANCELLED {
+		t.Fatalf("cancel returned %s", resp.State)
+	}
+
+	recvUntil(t, stream, ridev1.RideState_CANCELLED)
+
+	ride, err := env.service.store.Get(context.Background(), matched.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.State != store.StateCancelled || ride.CancelReason != "changed my mind" {
+		t.Fatalf("ride = %s %q, want CANCELLED with the rider's reason", ride.State, ride.CancelReason)
+	}
+	if released := env.drivers.released(); released != 1 {
+		t.Fatalf("driver released %d times, want 1", released)
+	}
+
+	_, err = env.client.Cancel(context.Background(), &ridev1.CancelRideRequest{RideId: matched.RideId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("second cancel returned %v, want FailedPrecondition", err)
+	}
+}
+
+func TestStartOffersRideToNextDriver(t *testing.T) {
+	env := newTestEnv(t, 2)
+	env.drivers.drivers = []*driverv1.DriverLocation{
+		{Name: "declines", Latitude: 47.15, Distance: 1},
+		{Name: "silent", Latitude: 47.14, Distance: 1.5},
+		{Name: "accepts", Latitude: 47.13, Distance: 2},
+	}
+	env.drivers.declines["declines"] = true
+	env.drivers.silent["silent"] = true
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+	if matched.Location.Name != "accepts" {
+		t.Fatalf("matched %s, want accepts", matched.Location.Name)
+	}
+
+	ride, err := env.service.store.Get(context.Background(), matched.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []store.OfferOutcome{store.OfferDeclined, store.OfferTimeout, store.OfferAccepted}
+	if len(ride.Offers) != len(want) {
+		t.Fatalf("recorded %d offers, want %d", len(ride.Offers), len(want))
+	}
+	for i, offer := range ride.Offers {
+		if offer.Outcome != want[i] {
+			t.Fatalf("offer %d to %s = %s, want %s", i, offer.Driver, offer.Outcome, want[i])
+		}
+	}
+
+	env.drivers.mu.Lock()
+	freed := append([]string(nil), env.drivers.freed...)
+	env.drivers.mu.Unlock()
+	if len(freed) != 2 || freed[0] != "declines" || freed[1] != "silent" {
+		t.Fatalf("freed %v, want the drivers that did not accept", freed)
+	}
+
+	accepted, err := env.rdb.HGet(context.Background(), offerStatsKey("accepts"), string(store.OfferAccepted)).Int()
+	if err != nil || accepted != 1 {
+		t.Fatalf("accepted offers of the driver = %d (%v), want 1", accepted, err)
+	}
+}
+
+func TestStartSearchesBeyondMaxRadiusOnce(t *testing.T) {
+	env := newTestEnv(t, 2)
+
+	req := startRideRequest()
+	req.StartLocation.Radius = 50
+	stream, err := env.client.Start(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if matched := recvUntil(t, stream, ridev1.RideState_MATCHED); matched.Location.Name != "driver-1" {
+		t.Fatalf("matched %s, want driver-1", matched.Location.Name)
+	}
+}
+
+func TestStartOffersRideToBestRankedDriver(t *testing.T) {
+	env := newTestEnv(t, 2)
+	cfg := config.Defaults(8082)
+	cfg.Ride.Ranking.Rating = 0.2
+	env.service.ranker = rank.NewRanker(&cfg)
+	env.drivers.drivers = []*driverv1.DriverLocation{
+		{Name: "closest", Latitude: 47.15, Distance: 1},
+		{Name: "best rated", Latitude: 47.14, Distance: 1.2},
+	}
+	ctx := context.Background()
+	env.rdb.HSet(ctx, ratingsKey("closest"), "sum", 10, "count", 10)
+	env.rdb.HSet(ctx, ratingsKey("best rated"), "sum", 50, "count", 10)
+
+	stream, err := env.client.Start(ctx, startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+	if matched.Location.Name != "best rated" {
+		t.Fatalf("matched %s, want best rated", matched.Location.Name)
+	}
+
+	ride, err := env.service.store.Get(ctx, matched.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ride.Rankings) != 1 || len(ride.Rankings[0].Candidates) != 2 {
+		t.Fatalf("rankings = %+v, want one round with both drivers", ride.Rankings)
+	}
+	best := ride.Rankings[0].Candidates[0]
+	if best.Driver != "best rated" || len(best.Factors) != 5 || best.Factors[rank.FACTOR_RATING] <= ride.Rankings[0].Candidates[1].Factors[rank.FACTOR_RATING] {
+		t.Fatalf("best candidate = %+v, want best rated with the breakdown of its score", best)
+	}
+}
+
+func TestStartMatchesBatchTogether(t *testing.T) {
+	env := newTestEnv(t, 1000)
+	env.drivers.drivers = []*driverv1.DriverLocation{
+		{Name: "driver-1", Latitude: 47.15, Distance: 1},
+		{Name: "driver-2", Latitude: 47.14, Distance: 2},
+	}
+	env.service.batcher = dispatch.NewBatcher(zap.NewNop(), 50*time.Millisecond, env.service.ranker, env.service.find)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	matched := make(chan string, 2)
+	for _, rider := range []string{"rider", "rider-2"} {
+		req := startRideRequest()
+		req.Username = rider
+		stream, err := env.dial(t, rider, token.RoleRider).Start(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					matched <- err.Error()
+					return
+				}
+				if resp.State == ridev1.RideState_MATCHED {
+					matched <- resp.Location.Name
+					return
+				}
+			}
+		}()
+	}
+
+	first, second := <-matched, <-matched
+	if first == second || (first != "driver-1" && first != "driver-2") || (second != "driver-1" && second != "driver-2") {
+		t.Fatalf("the rides got %s and %s, want one driver each", first, second)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	env := newTestEnv(t, 2)
+	ctx := context.Background()
+	req := startRideRequest()
+
+	estimate, err := env.client.Estimate(ctx, &ridev1.EstimateRequest{StartLocation: req.StartLocation, EndLocation: req.EndLocation})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.Route == nil || estimate.Route.Distance <= 0 {
+		t.Fatalf("route = %+v, want the one of the ride", estimate.Route)
+	}
+	if len(estimate.Fares) != 3 {
+		t.Fatalf("fares = %+v, want one per vehicle class", estimate.Fares)
+	}
+	for idx, vehicle := range []driverv1.VehicleType{driverv1.VehicleType_STANDARD, driverv1.VehicleType_COMFORT, driverv1.VehicleType_XL} {
+		fare := estimate.Fares[idx]
+		if fare.Vehicle != vehicle || fare.City != "iasi" || fare.Total <= 0 {
+			t.Fatalf("fare %d = %+v, want a %s one in iasi", idx, fare, vehicle)
+		}
+		if idx > 0 && fare.Total <= estimate.Fares[idx-1].Total {
+			t.Fatalf("fares = %+v, want the better classes to cost more", estimate.Fares)
+		}
+	}
+
+	only, err := env.client.Estimate(ctx, &ridev1.EstimateRequest{
+		StartLocation: req.StartLocation,
+		EndLocation:   req.EndLocation,
+		VehicleTypes:  []driverv1.VehicleType{driverv1.VehicleType_XL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(only.Fares) != 1 || only.Fares[0].Total != estimate.Fares[2].Total {
+		t.Fatalf("fares = %+v, want the xl one", only.Fares)
+	}
+
+	bucharest := &driverv1.LocationMetadata{Latitude: 44.43, Longitude: 26.1}
+	_, err = env.client.Estimate(ctx, &ridev1.EstimateRequest{StartLocation: bucharest, EndLocation: req.EndLocation})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("estimate outside of the cities returned %v, want InvalidArgument", err)
+	}
+}
+
+func TestStartRequiresToken(t *testing.T) {
+	env := newTestEnv(t, 2)
+
+	stream, err := env.dial(t, "", "").Start(context.Background(), startRideRequest())
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("start without a token returned %v, want Unauthenticated", err)
+	}
+}
+
+func TestStartAsAnotherRiderIsDenied(t *testing.T) {
+	env := newTestEnv(t, 2)
+
+	stream, err := env.dial(t, "mallory", token.RoleRider).Start(context.Background(), startRideRequest())
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("start as another rider returned %v, want PermissionDenied", err)
+	}
+
+	stream, err = env.dial(t, "driver-1", token.RoleDriver).Start(context.Background(), startRideRequest())
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("start as a driver returned %v, want PermissionDenied", err)
+	}
+}
+
+func TestCancelOnlyOwnRide(t *testing.T) {
+	env := newTestEnv(t, 1000)
+
+	stream, err := env.client.Start(context.Background(), startRideRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched := recvUntil(t, stream, ridev1.RideState_MATCHED)
+
+	_, err = env.dial(t, "mallory", token.RoleRider).Cancel(context.Background(), &ridev1.CancelRideRequest{RideId: matched.RideId})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("cancel by another rider returned %v, want PermissionDenied", err)
+	}
+
+	// the driver of the ride can cancel it, the actor is taken from the token and not the request
+	_, err = env.dial(t, "driver-1", token.RoleDriver).Cancel(context.Background(), &ridev1.CancelRideRequest{
+		RideId: matched.RideId,
+		Actor:  ridev1.CancelActor_SYSTEM,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ride, err := env.service.store.Get(context.Background(), matched.RideId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ride.CancelledBy != ridev1.CancelActor_DRIVER.String() {
+		t.Fatalf("ride cancelled by %s, want DRIVER", ride.CancelledBy)
+	}
+}
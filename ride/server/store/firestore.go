@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const RIDES_COLLECTION = "rides"
+
+type FirestoreStore struct {
+	client *firestore.Client
+}
+
+func NewFirestoreStore(client *firestore.Client) *FirestoreStore {
+	return &FirestoreStore{
+		client: client,
+	}
+}
+
+func (s *FirestoreStore) Create(ctx context.Context, ride *Ride) error {
+	_, err := s.client.Collection(RIDES_COLLECTION).Doc(ride.ID).Create(ctx, ride)
+	return err
+}
+
+func (s *FirestoreStore) Get(ctx context.Context, id string) (*Ride, error) {
+	snapshot, err := s.client.Collection(RIDES_COLLECTION).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ride Ride
+	if err := snapshot.DataTo(&ride); err != nil {
+		return nil, err
+	}
+
+	return &ride, nil
+}
+
+func (s *FirestoreStore) Update(ctx context.Context, id string, fn func(*Ride) error) (*Ride, error) {
+	doc := s.client.Collection(RIDES_COLLECTION).Doc(id)
+
+	var ride Ride
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(doc)
+		if status.Code(err) == codes.NotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		ride = Ride{}
+		if err := snapshot.DataTo(&ride); err != nil {
+			return err
+		}
+
+		if err := fn(&ride); err != nil {
+			return err
+		}
+		ride.UpdatedAt = time.Now()
+
+		return tx.Set(doc, &ride)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ride, nil
+}
+
+func (s *FirestoreStore) Transition(ctx context.Context, id string, to State, fn func(*Ride)) (*Ride, error) {
+	return s.Update(ctx, id, transition(to, fn))
+}
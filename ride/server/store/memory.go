@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the rides in the process, for running without a database
+type MemoryStore struct {
+	mu sync.Mutex
+	// rides are kept encoded so callers never share a ride with the store
+	rides map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		rides: make(map[string][]byte),
+	}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, ride *Ride) error {
+	data, err := json.Marshal(ride)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rides[ride.ID]; ok {
+		return fmt.Errorf("ride %s already exists", ride.ID)
+	}
+	s.rides[ride.ID] = data
+
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Ride, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.get(id)
+}
+
+func (s *MemoryStore) Update(ctx context.Context, id string, fn func(*Ride) error) (*Ride, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ride, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(ride); err != nil {
+		return nil, err
+	}
+	ride.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(ride)
+	if err != nil {
+		return nil, err
+	}
+	s.rides[id] = data
+
+	return ride, nil
+}
+
+func (s *MemoryStore) Transition(ctx context.Context, id string, to State, fn func(*Ride)) (*Ride, error) {
+	return s.Update(ctx, id, transition(to, fn))
+}
+
+func (s *MemoryStore) get(id string) (*Ride, error) {
+	data, ok := s.rides[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	var ride Ride
+	if err := json.Unmarshal(data, &ride); err != nil {
+		return nil, err
+	}
+
+	return &ride, nil
+}
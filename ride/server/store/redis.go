@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const MAX_UPDATE_RETRIES = 10
+
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{
+		rdb: rdb,
+	}
+}
+
+func rideKey(id string) string {
+	return fmt.Sprintf("rides/%s", id)
+}
+
+func (s *RedisStore) Create(ctx context.Context, ride *Ride) error {
+	data, err := json.Marshal(ride)
+	if err != nil {
+		return err
+	}
+
+	created, err := s.rdb.SetNX(ctx, rideKey(ride.ID), data, 0*time.Second).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return fmt.Errorf("ride %s already exists", ride.ID)
+	}
+
+	return nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Ride, error) {
+	return get(ctx, s.rdb, id)
+}
+
+func (s *RedisStore) Update(ctx context.Context, id string, fn func(*Ride) error) (*Ride, error) {
+	key := rideKey(id)
+
+	var ride *Ride
+	txf := func(tx *redis.Tx) error {
+		var err error
+		ride, err = get(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(ride); err != nil {
+			return err
+		}
+		ride.UpdatedAt = time.Now()
+
+		data, err := json.Marshal(ride)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0*time.Second)
+			return nil
+		})
+		return err
+	}
+
+	// optimistic locking, retry when another writer changed the ride in between
+	for i := 0; i < MAX_UPDATE_RETRIES; i++ {
+		err := s.rdb.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ride, nil
+	}
+
+	return nil, fmt.Errorf("ride %s: too many concurrent updates", id)
+}
+
+func (s *RedisStore) Transition(ctx context.Context, id string, to State, fn func(*Ride)) (*Ride, error) {
+	return s.Update(ctx, id, transition(to, fn))
+}
+
+func get(ctx context.Context, rdb redis.Cmdable, id string) (*Ride, error) {
+	data, err := rdb.Get(ctx, rideKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ride Ride
+	if err := json.Unmarshal(data, &ride); err != nil {
+		return nil, err
+	}
+
+	return &ride, nil
+}
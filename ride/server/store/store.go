@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type State string
+
+const (
+	StateRequested     State = "REQUESTED"
+	StateMatched       State = "MATCHED"
+	StateDriverEnRoute State = "DRIVER_EN_ROUTE"
+	StateInProgress    State = "IN_PROGRESS"
+	StateCompleted     State = "COMPLETED"
+	StateCancelled     State = "CANCELLED"
+	StateAborted       State = "ABORTED" // stopped by a failure, e.g. the rider hung up
+)
+
+// transitions lists the legal next states of every non terminal state
+var transitions = map[State][]State{
+	StateRequested:     {StateMatched, StateCancelled, StateAborted},
+	StateMatched:       {StateDriverEnRoute, StateCancelled, StateAborted},
+	StateDriverEnRoute: {StateInProgress, StateCancelled, StateAborted},
+	StateInProgress:    {StateCompleted, StateCancelled, StateAborted},
+}
+
+func (s State) CanTransition(to State) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s State) Terminal() bool {
+	return len(transitions[s]) == 0
+}
+
+var ErrNotFound = errors.New("ride not found")
+
+type TransitionError struct {
+	RideID string
+	From   State
+	To     State
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("ride %s cannot go from %s to %s", e.RideID, e.From, e.To)
+}
+
+type Location struct {
+	Latitude  float64 `json:"latitude" firestore:"latitude"`
+	Longitude float64 `json:"longitude" firestore:"longitude"`
+}
+
+type StateChange struct {
+	State     State     `json:"state" firestore:"state"`
+	Timestamp time.Time `json:"timestamp" firestore:"timestamp"`
+}
+
+type OfferOutcome string
+
+const (
+	OfferAccepted    OfferOutcome = "ACCEPTED"
+	OfferDeclined    OfferOutcome = "DECLINED"
+	OfferTimeout     OfferOutcome = "TIMEOUT"
+	OfferUnavailable OfferOutcome = "UNAVAILABLE" // the driver had no open session
+	OfferFailed      OfferOutcome = "FAILED"
+)
+
+// Offer is a ride proposed to a driver during matching
+type Offer struct {
+	Driver      string       `json:"driver" firestore:"driver"`
+	Distance    float64      `json:"distance" firestore:"distance"`
+	Outcome     OfferOutcome `json:"outcome" firestore:"outcome"`
+	OfferedAt   time.Time    `json:"offeredAt" firestore:"offeredAt"`
+	RespondedAt time.Time    `json:"respondedAt" firestore:"respondedAt"`
+}
+
+// CandidateScore is how a driver scored when the ride was dispatched, with the weighted value of every factor
+type CandidateScore struct {
+	Driver  string             `json:"driver" firestore:"driver"`
+	Total   float64            `json:"total" firestore:"total"`
+	Factors map[string]float64 `json:"factors" firestore:"factors"`
+}
+
+// Ranking is a round of matching, the candidates found in the search radius ordered best first
+type Ranking struct {
+	Radius     float64          `json:"radius" firestore:"radius"`
+	RankedAt   time.Time        `json:"rankedAt" firestore:"rankedAt"`
+	Candidates []CandidateScore `json:"candidates" firestore:"candidates"`
+}
+
+// Fare is the price of a ride, the amounts are in the minor unit of the currency
+type Fare struct {
+	Currency             string `json:"currency" firestore:"currency"`
+	City                 string `json:"city" firestore:"city"`
+	Vehicle              string `json:"vehicle" firestore:"vehicle"`
+	BaseFare             int64  `json:"baseFare" firestore:"baseFare"`
+	DistanceFare         int64  `json:"distanceFare" firestore:"distanceFare"`
+	TimeFare             int64  `json:"timeFare" firestore:"timeFare"`
+	MinimumFareSurcharge int64  `json:"minimumFareSurcharge" firestore:"minimumFareSurcharge"`
+	BookingFee           int64  `json:"bookingFee" firestore:"bookingFee"`
+	Total                int64  `json:"total" firestore:"total"`
+}
+
+type Ride struct {
+	ID              string        `json:"id" firestore:"id"`
+	Rider           string        `json:"rider" firestore:"rider"`
+	Driver          string        `json:"driver" firestore:"driver"`
+	State           State         `json:"state" firestore:"state"`
+	Start           Location      `json:"start" firestore:"start"`
+	End             Location      `json:"end" firestore:"end"`
+	Distance        float64       `json:"distance" firestore:"distance"`
+	DurationSeconds int64         `json:"durationSeconds" firestore:"durationSeconds"`
+	History         []StateChange `json:"history" firestore:"history"`
+	Offers          []Offer       `json:"offers" firestore:"offers"`
+	Rankings        []Ranking     `json:"rankings" firestore:"rankings"`
+	CreatedAt       time.Time     `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt" firestore:"updatedAt"`
+
+	// set when the ride is cancelled or aborted
+	CancelReason string `json:"cancelReason,omitempty" firestore:"cancelReason,omitempty"`
+	CancelledBy  string `json:"cancelledBy,omitempty" firestore:"cancelledBy,omitempty"`
+
+	// what the ride cost, set when it is completed
+	Fare *Fare `json:"fare,omitempty" firestore:"fare,omitempty"`
+}
+
+func NewRide(id string, rider string, start Location, end Location) *Ride {
+	now := time.Now()
+	return &Ride{
+		ID:        id,
+		Rider:     rider,
+		State:     StateRequested,
+		Start:     start,
+		End:       end,
+		History:   []StateChange{{State: StateRequested, Timestamp: now}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Store persists rides and guards their lifecycle.
+// Update and Transition are atomic with respect to other writers of the same ride.
+type Store interface {
+	Create(ctx context.Context, ride *Ride) error
+	Get(ctx context.Context, id string) (*Ride, error)
+	Update(ctx context.Context, id string, fn func(*Ride) error) (*Ride, error)
+	// Transition moves the ride to the given state and applies fn in the same write.
+	// It returns a *TransitionError when the move is not allowed from the current state.
+	Transition(ctx context.Context, id string, to State, fn func(*Ride)) (*Ride, error)
+}
+
+func transition(to State, fn func(*Ride)) func(*Ride) error {
+	return func(ride *Ride) error {
+		if !ride.State.CanTransition(to) {
+			return &TransitionError{RideID: ride.ID, From: ride.State, To: to}
+		}
+
+		ride.State = to
+		ride.History = append(ride.History, StateChange{State: to, Timestamp: time.Now()})
+		if fn != nil {
+			fn(ride)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var states = []State{StateRequested, StateMatched, StateDriverEnRoute, StateInProgress, StateCompleted, StateCancelled, StateAborted}
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[State][]State{
+		StateRequested:     {StateMatched, StateCancelled, StateAborted},
+		StateMatched:       {StateDriverEnRoute, StateCancelled, StateAborted},
+		StateDriverEnRoute: {StateInProgress, StateCancelled, StateAborted},
+		StateInProgress:    {StateCompleted, StateCancelled, StateAborted},
+	}
+
+	for _, from := range states {
+		for _, to := range states {
+			want := false
+			for _, next := range allowed[from] {
+				want = want || next == to
+			}
+			if got := from.CanTransition(to); got != want {
+				t.Errorf("%s.CanTransition(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	tests := []struct {
+		state State
+		want  bool
+	}{
+		{StateRequested, false},
+		{StateMatched, false},
+		{StateDriverEnRoute, false},
+		{StateInProgress, false},
+		{StateCompleted, true},
+		{StateCancelled, true},
+		{StateAborted, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.state.Terminal(); got != tt.want {
+			t.Errorf("%s.Terminal() = %v, want %v", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []State
+		to      State
+		allowed bool
+	}{
+		{"matched", nil, StateMatched, true},
+		{"en route", []State{StateMatched}, StateDriverEnRoute, true},
+		{"in progress", []State{StateMatched, StateDriverEnRoute}, StateInProgress, true},
+		{"completed", []State{StateMatched, StateDriverEnRoute, StateInProgress}, StateCompleted, true},
+		{"cancelled while requested", nil, StateCancelled, true},
+		{"aborted in progress", []State{StateMatched, StateDriverEnRoute, StateInProgress}, StateAborted, true},
+		{"skipping a state", nil, StateInProgress, false},
+		{"going back", []State{StateMatched, StateDriverEnRoute}, StateMatched, false},
+		{"staying", []State{StateMatched}, StateMatched, false},
+		{"after completed", []State{StateMatched, StateDriverEnRoute, StateInProgress, StateCompleted}, StateCancelled, false},
+		{"after cancelled", []State{StateCancelled}, StateMatched, false},
+		{"after aborted", []State{StateAborted}, StateAborted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := NewMemoryStore()
+			if err := s.Create(ctx, NewRide("ride", "rider", Location{}, Location{})); err != nil {
+				t.Fatal(err)
+			}
+			for _, state := range tt.path {
+				if _, err := s.Transition(ctx, "ride", state, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			from := StateRequested
+			if len(tt.path) > 0 {
+				from = tt.path[len(tt.path)-1]
+			}
+
+			applied := false
+			ride, err := s.Transition(ctx, "ride", tt.to, func(*Ride) { applied = true })
+
+			if !tt.allowed {
+				var transitionErr *TransitionError
+				if !errors.As(err, &transitionErr) || transitionErr.From != from || transitionErr.To != tt.to {
+					t.Fatalf("Transition() = %v, want a TransitionError from %s to %s", err, from, tt.to)
+				}
+				if applied {
+					t.Fatal("Transition() applied fn on a rejected transition")
+				}
+				if ride, _ := s.Get(ctx, "ride"); ride.State != from || len(ride.History) != len(tt.path)+1 {
+					t.Fatalf("ride = %s with %d changes, want it left %s", ride.State, len(ride.History), from)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !applied || ride.State != tt.to || ride.History[len(ride.History)-1].State != tt.to {
+				t.Fatalf("ride = %s, history %v, want it moved to %s with fn applied", ride.State, ride.History, tt.to)
+			}
+		})
+	}
+}
+
+func TestTransitionNotFound(t *testing.T) {
+	if _, err := NewMemoryStore().Transition(context.Background(), "missing", StateMatched, nil); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Transition() = %v, want ErrNotFound", err)
+	}
+}